@@ -13,13 +13,13 @@
 //
 // - Fyne compatible widgets:
 //
-//   - SizableLabel, MiniWidget, FlexButton, ListableSearchableWidget, MainRibbon, SideBar
+//   - SizableLabel, MiniWidget, FlexButton, ListableSearchableWidget, MainRibbon, SideBar, Backstage, ActionSearchEntry, Badge, DockManager
 //
 //   - Utilities:
 //
 //   - FormGenUtility,
 //
-//   - ActionItem, ActionableMenu
+//   - ActionItem, ActionableMenu, RibbonBuilder, RibbonManager, ToolTipManager
 //
 // Example:
 package fyneextensions
@@ -4,7 +4,6 @@ import (
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
 	"fyne.io/fyne/v2/container"
-	"fyne.io/fyne/v2/data/binding"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 	"github.com/acs48/fyneextensions"
@@ -13,6 +12,41 @@ import (
 	"time"
 )
 
+type fileAction struct {
+	mAction *fyneextensions.ActionItem
+	w       fyne.Window
+}
+
+func newFileAction(w fyne.Window) *fileAction {
+	infoItem := fyneextensions.NewActionItem("Info", false, false, []fyne.Resource{theme.InfoIcon()}, false, false, false, 0, nil, nil)
+	infoItem.Content = func() fyne.CanvasObject {
+		return widget.NewLabel("fyneextensions demo")
+	}
+	optionsItem := fyneextensions.NewActionItem("Options", false, false, []fyne.Resource{theme.SettingsIcon()}, false, false, false, 0, nil, nil)
+	optionsItem.Content = func() fyne.CanvasObject {
+		return widget.NewCheck("Demo option", func(bool) {})
+	}
+	rv := &fileAction{
+		w: w,
+		mAction: fyneextensions.NewActionItem("File", false, false, []fyne.Resource{}, false, false, false, 0, nil, []*fyneextensions.ActionItem{
+			infoItem,
+			fyneextensions.NewActionItem("Open", false, false, []fyne.Resource{theme.FolderOpenIcon()}, false, false, false, 0, func(int) {}, nil),
+			fyneextensions.NewActionItem("Save as", false, false, []fyne.Resource{theme.DocumentSaveIcon()}, false, false, false, 0, func(int) {}, nil),
+			fyneextensions.NewActionItem("Print", false, false, []fyne.Resource{theme.DocumentPrintIcon()}, false, false, false, 0, func(int) {}, nil),
+			optionsItem,
+		}),
+	}
+	return rv
+}
+
+func (fa *fileAction) GetActions() *fyneextensions.ActionItem {
+	return fa.mAction
+}
+
+func (fa *fileAction) GetCanvas() fyne.Canvas {
+	return fa.w.Canvas()
+}
+
 type homeAction struct {
 	mAction *fyneextensions.ActionItem
 	w       fyne.Window
@@ -346,28 +380,20 @@ func (dm *sampleList) StartSearch(text string) {
 // Example function demonstrating the fyneextensions widgets
 func main() {
 	// Instantiate the Fyne application
-	a := app.New()
+	a := app.NewWithID("com.github.acs48.fyneextensions.demo")
 
 	// Create a new window
 	w := a.NewWindow("Fyne Window")
 
 	// Set the main window content
-	messageString := binding.NewString()
-	messageLabel := widget.NewLabelWithData(messageString)
-
 	mHomeAction := newHomeAction(w)
 	mEditAction := newEditAction(w)
 	mDemoAction := newDemoAction(w)
-	mRibbon := container.NewAppTabs()
-	homeTab, _ := fyneextensions.BuildTabItemRibbon(mHomeAction, 60., 30., messageString)
-	homeMenu := fyneextensions.NewActionableMenu(mHomeAction.GetActions())
-	mRibbon.Append(homeTab)
-	editTab, editRb := fyneextensions.BuildTabItemRibbon(mEditAction, 60., 30., messageString)
-	editMenu := fyneextensions.NewActionableMenu(mEditAction.GetActions())
-	mRibbon.Append(editTab)
-	demoTab, _ := fyneextensions.BuildTabItemRibbon(mDemoAction, 60., 30., messageString)
-	demoMenu := fyneextensions.NewActionableMenu(mDemoAction.GetActions())
-	mRibbon.Append(demoTab)
+	ribbonManager := fyneextensions.NewRibbonManager(60., 30., mHomeAction, mEditAction, mDemoAction)
+	mRibbon := ribbonManager.Tabs
+	editRb := ribbonManager.Ribbon(mEditAction)
+	messageLabel := widget.NewLabelWithData(ribbonManager.ToolTipper)
+	fyneextensions.BuildTabItemBackstage(newFileAction(w), mRibbon)
 
 	editRb.AddItems(
 		fyneextensions.NewActionItem("runtime add", true, false, []fyne.Resource{theme.InfoIcon()}, false, false, false, 0, nil, []*fyneextensions.ActionItem{
@@ -411,18 +437,36 @@ func main() {
 	searchList := fyneextensions.NewListableSearchableWidget(listItem)
 	searchWidget := fyneextensions.NewMiniWidget("ITEMS", true, 20., searchList, false, true, nil, false, nil, true, nil, nil, false, nil, nil, nil, nil, nil, w.Canvas())
 
+	widgetTree.ID = "project"
+	searchWidget.ID = "items"
+	widgetTree.SetIcon(theme.FolderIcon())
+	searchWidget.SetIcon(theme.SearchIcon())
+	widgetTree.SetDetachable(true)
+	searchWidget.SetDetachable(true)
+	searchWidget.SetHeaderActions(fyneextensions.NewActionItem("", false, false, nil, false, false, false, 0, nil, []*fyneextensions.ActionItem{
+		fyneextensions.NewActionItem("Clear search", false, false, []fyne.Resource{theme.ContentUndoIcon()}, false, false, false, 0, func(int) {
+			listItem.ClearSearch()
+			searchList.Refresh()
+		}, nil),
+	}))
+
 	mainContent := container.NewStack()
 	sideContent := fyneextensions.NewSideBar(widgetTree, searchWidget)
+	sideContent.CollapseWidth = 120.
+	sideContent.FlyoutOnClick = true
 	split := container.NewHSplit(sideContent, mainContent)
+	_ = sideContent.RestoreLayoutFromPreferences(a.Preferences(), "sidebarLayout")
+	w.SetOnClosed(func() {
+		_ = sideContent.SaveLayoutToPreferences(a.Preferences(), "sidebarLayout")
+	})
+
+	ribbonArea := fyneextensions.NewRibbonWithActionSearch(mRibbon, fyneextensions.NewActionSearchEntry(w.Canvas(), ribbonManager.Actionables), 250.)
 
-	mainContainer := container.NewBorder(mRibbon, messageLabel, nil, nil, mRibbon, messageLabel, split)
+	mainContainer := container.NewBorder(ribbonArea, messageLabel, nil, nil, ribbonArea, messageLabel, split)
 
-	w.SetContent(mainContainer)
-	w.SetMainMenu(fyne.NewMainMenu(
-		homeMenu.Menu,
-		editMenu.Menu,
-		demoMenu.Menu,
-	))
+	w.SetContent(fyneextensions.AddToolTipLayer(mainContainer, w.Canvas()))
+	ribbonManager.MainMenu.Items = append(ribbonManager.MainMenu.Items, fyneextensions.NewActionableMenu(sideContent.ViewAction()).Menu)
+	w.SetMainMenu(ribbonManager.MainMenu)
 	// Show and run the application
 	w.ShowAndRun()
 
@@ -3,6 +3,7 @@ package fyneextensions
 import (
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/widget"
 )
 
 /*
@@ -28,7 +29,12 @@ The fields are as follows:
 - CriticalName is a bool that determines if the name is critical and should always be rendered.
 - AlwaysShowAsContainer is a bool that defines if the action should always be represented as a container even if there are no sub-actions.
 - Resources is a slice of fyne.Resource which can be used for representing the action in UI, like using an icon. The state of the item will force the related resource to be shown
+- DisabledResources optionally defines the resources shown when the action is disabled, one per state. When not defined a greyed version of Resources is shown.
 - Triggered is a function that will be invoked when the action is triggered.
+- DoubleTriggered is an optional function invoked when the ribbon button of the action is double tapped.
+- Importance defines the style of the FlexButton built for the action, as widget.Button Importance.
+- Badge is an optional Badge used as template: every FlexButton or ribbon group built for the action shows a copy of it.
+- Content is an optional function returning the object to be shown when the action is selected in a Backstage.
 - SubActions are nested actions.
 - HasDynamicStates is a bool that defines if the action has dynamic states that can change.
 - Disabler, Hider, and Stater are binding variables which provide a way to control the disabled, hidden, and state properties of an action and observe changes to these properties.
@@ -40,13 +46,20 @@ type ActionItem struct {
 	CriticalName          bool
 	AlwaysShowAsContainer bool
 	Resources             []fyne.Resource
+	DisabledResources     []fyne.Resource
 	Triggered             func(int)
+	DoubleTriggered       func(int)
+	Content               func() fyne.CanvasObject
+	Badge                 *Badge
+	Importance            widget.Importance
 	SubActions            []*ActionItem
 	HasDynamicStates      bool
 
 	Disabler binding.Bool
 	Hider    binding.Bool
 	Stater   binding.Int
+
+	mChanged binding.Int // revision of SubActions, incremented by AppendActions and RemoveActions
 }
 
 // NewActionItem function is a factory function for creating new action items.
@@ -72,7 +85,34 @@ func NewActionItem(name string, isNameCritical, alwaysShowAsContainer bool, reso
 	return
 }
 
-// AppendActions method is to append sub actions to an existing ActionItem.
+// AppendActions method is to append sub actions to an existing ActionItem. ActionableMenu built from the item are updated.
 func (ai *ActionItem) AppendActions(subActions ...*ActionItem) {
 	ai.SubActions = append(ai.SubActions, subActions...)
+	ai.notifyChanged()
+}
+
+// RemoveActions removes sub actions from an existing ActionItem. ActionableMenu built from the item are updated.
+func (ai *ActionItem) RemoveActions(subActions ...*ActionItem) {
+	for _, r := range subActions {
+		for i, o := range ai.SubActions {
+			if o == r {
+				ai.SubActions = append(ai.SubActions[:i], ai.SubActions[i+1:]...)
+				break
+			}
+		}
+	}
+	ai.notifyChanged()
+}
+
+// changed returns the binding notifying the changes of SubActions made with AppendActions and RemoveActions
+func (ai *ActionItem) changed() binding.Int {
+	if ai.mChanged == nil {
+		ai.mChanged = binding.NewInt()
+	}
+	return ai.mChanged
+}
+
+func (ai *ActionItem) notifyChanged() {
+	rev, _ := ai.changed().Get()
+	ai.changed().Set(rev + 1)
 }
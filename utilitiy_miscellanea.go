@@ -1,8 +1,15 @@
 package fyneextensions
 
 import (
+	"bytes"
 	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+	"hash/fnv"
+	"image"
 	"image/color"
+	_ "image/jpeg"
+	"image/png"
+	"sync"
 )
 
 func DarkTheme(fallback fyne.Theme) fyne.Theme {
@@ -23,6 +30,27 @@ func (f *forcedVariant) Color(name fyne.ThemeColorName, _ fyne.ThemeVariant) col
 	return f.Theme.Color(name, f.variant)
 }
 
+/*
+runOnEventQueue runs fn on the event goroutine of the window showing mCanvas, where fyne calls the widget event
+handlers, so that code started by timers does not race with them. Fyne 2.4 has no public API for it, so the event
+queue of the desktop driver is used when available, otherwise fn is called directly
+*/
+func runOnEventQueue(mCanvas fyne.Canvas, fn func()) {
+	if app := fyne.CurrentApp(); app != nil && mCanvas != nil {
+		for _, w := range app.Driver().AllWindows() {
+			if w.Canvas() != mCanvas {
+				continue
+			}
+			if q, ok := w.(interface{ QueueEvent(func()) }); ok {
+				q.QueueEvent(fn)
+				return
+			}
+			break
+		}
+	}
+	fn()
+}
+
 func blendColor(under, over color.Color) color.Color {
 	// This alpha blends with the over operator, and accounts for RGBA() returning alpha-premultiplied values
 	dstR, dstG, dstB, dstA := under.RGBA()
@@ -130,3 +158,131 @@ func unmultiplyAlpha(c color.Color) (r, g, b, a int) {
 	a = int(alpha >> 8)
 	return
 }
+
+type derivedResourceKind int
+
+const (
+	themedResourceKind derivedResourceKind = iota
+	invertedResourceKind
+	greyedResourceKind
+)
+
+// derivedResourceKey identifies a resource by name and content, as resources are not always comparable
+type derivedResourceKey struct {
+	name string
+	hash uint64
+	kind derivedResourceKind
+}
+
+// maxDerivedResources is the number of derived resources kept in cache; the oldest ones are dropped first
+const maxDerivedResources = 256
+
+/*
+derivedResources caches the themed, inverted and greyed versions of the resources, so that refreshing a widget does
+not allocate new resources. Theme-aware resources follow the theme variant on their own
+*/
+var (
+	derivedResources      = map[derivedResourceKey]fyne.Resource{}
+	derivedResourcesOrder []derivedResourceKey
+	derivedResourcesLock  sync.Mutex
+)
+
+// derivedResource returns the cached kind version of res, creating it with create the first time
+func derivedResource(res fyne.Resource, kind derivedResourceKind, create func() fyne.Resource) fyne.Resource {
+	h := fnv.New64a()
+	h.Write(res.Content())
+	key := derivedResourceKey{name: res.Name(), hash: h.Sum64(), kind: kind}
+
+	derivedResourcesLock.Lock()
+	dr, ok := derivedResources[key]
+	derivedResourcesLock.Unlock()
+	if ok {
+		return dr
+	}
+
+	dr = create()
+	derivedResourcesLock.Lock()
+	defer derivedResourcesLock.Unlock()
+	if cached, ok := derivedResources[key]; ok {
+		return cached
+	}
+	if len(derivedResourcesOrder) >= maxDerivedResources {
+		delete(derivedResources, derivedResourcesOrder[0])
+		derivedResourcesOrder = derivedResourcesOrder[1:]
+	}
+	derivedResources[key] = dr
+	derivedResourcesOrder = append(derivedResourcesOrder, key)
+	return dr
+}
+
+// isSVGResource returns true if the resource content is an SVG image
+func isSVGResource(res fyne.Resource) bool {
+	content := res.Content()
+	if len(content) > 512 {
+		content = content[:512]
+	}
+	return bytes.Contains(content, []byte("<svg"))
+}
+
+/*
+themedResource wraps an SVG resource in a theme.ThemedResource, so that it is colored with the theme foreground
+color and follows theme variant changes. Resources already theme-aware and raster images are returned as they are.
+Results are cached
+*/
+func themedResource(res fyne.Resource) fyne.Resource {
+	switch res.(type) {
+	case *theme.ThemedResource, *theme.InvertedThemedResource, *theme.DisabledResource, *theme.ErrorThemedResource, *theme.PrimaryThemedResource:
+		return res
+	}
+	return derivedResource(res, themedResourceKind, func() fyne.Resource {
+		if isSVGResource(res) {
+			return theme.NewThemedResource(res)
+		}
+		return res
+	})
+}
+
+// invertedResource returns the cached inverted version of a themed resource
+func invertedResource(res *theme.ThemedResource) fyne.Resource {
+	return derivedResource(res, invertedResourceKind, func() fyne.Resource {
+		return theme.NewInvertedThemedResource(res)
+	})
+}
+
+/*
+greyedResource returns the disabled version of a resource: SVG images are colored with the theme disabled color,
+raster images are converted to grey scale at half opacity. Results are cached
+*/
+func greyedResource(res fyne.Resource) fyne.Resource {
+	return derivedResource(res, greyedResourceKind, func() fyne.Resource {
+		return createGreyedResource(res)
+	})
+}
+
+func createGreyedResource(res fyne.Resource) fyne.Resource {
+	if isSVGResource(res) {
+		return theme.NewDisabledResource(res)
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(res.Content()))
+	if err != nil {
+		fyne.LogError("cannot decode image "+res.Name(), err)
+		return res
+	}
+	bounds := src.Bounds()
+	dst := image.NewNRGBA(bounds)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			r, g, b, a := ToNRGBA(src.At(x, y))
+			grey := uint8((299*r + 587*g + 114*b) / 1000)
+			dst.SetNRGBA(x, y, color.NRGBA{R: grey, G: grey, B: grey, A: uint8(a / 2)})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, dst); err != nil {
+		fyne.LogError("cannot encode image "+res.Name(), err)
+		return res
+	}
+
+	return fyne.NewStaticResource("disabled_"+res.Name(), buf.Bytes())
+}
@@ -13,6 +13,9 @@ type ActionableMenuItem struct {
 	subActionableMenuItems []*ActionableMenuItem
 
 	mItem *fyne.MenuItem
+
+	mRoot     binding.DataListener
+	mRevision int
 }
 
 /*
@@ -27,6 +30,7 @@ adjusting menu items based on the program state
 func NewActionableMenuItem(item *ActionItem, parentItem binding.DataListener, rootItem binding.DataListener) *ActionableMenuItem {
 	ami := &ActionableMenuItem{
 		mActionItem: item,
+		mRoot:       rootItem,
 	}
 
 	ami.mItem = fyne.NewMenuItem("dummy", nil)
@@ -58,6 +62,11 @@ func NewActionableMenuItem(item *ActionItem, parentItem binding.DataListener, ro
 			ami.mActionItem.Name.AddListener(rootItem)
 		}
 	}
+	ami.mRevision, _ = ami.mActionItem.changed().Get()
+	ami.mActionItem.changed().AddListener(ami)
+	if rootItem != nil {
+		ami.mActionItem.changed().AddListener(rootItem)
+	}
 	if ami.mActionItem.Disabler != nil {
 		ami.mActionItem.Disabler.AddListener(ami)
 		//if parentItem != nil {
@@ -82,7 +91,50 @@ func NewActionableMenuItem(item *ActionItem, parentItem binding.DataListener, ro
 	return ami
 }
 
+/*
+syncSubItems updates the sub-items, recursively, to the SubActions of the ActionItem changed by AppendActions or
+RemoveActions since they were built. Sub-items of ActionItem not changed are left as they are
+*/
+func (ami *ActionableMenuItem) syncSubItems() {
+	for _, o := range ami.subActionableMenuItems {
+		o.syncSubItems()
+	}
+
+	rev, _ := ami.mActionItem.changed().Get()
+	if rev == ami.mRevision {
+		return
+	}
+	ami.mRevision = rev
+
+	existing := map[*ActionItem]*ActionableMenuItem{}
+	for _, o := range ami.subActionableMenuItems {
+		existing[o.mActionItem] = o
+	}
+
+	subItems := make([]*ActionableMenuItem, 0, len(ami.mActionItem.SubActions))
+	for _, o := range ami.mActionItem.SubActions {
+		if sub, ok := existing[o]; ok {
+			delete(existing, o)
+			subItems = append(subItems, sub)
+		} else {
+			subItems = append(subItems, NewActionableMenuItem(o, ami, ami.mRoot))
+		}
+	}
+	for _, o := range existing {
+		o.unbind(ami, ami.mRoot)
+	}
+	ami.subActionableMenuItems = subItems
+
+	if len(subItems) == 0 {
+		ami.mItem.ChildMenu = nil
+	} else if ami.mItem.ChildMenu == nil {
+		ami.mItem.ChildMenu = fyne.NewMenu("dummy")
+		ami.mItem.ChildMenu.Items = make([]*fyne.MenuItem, MaxMenuItems)[0:0]
+	}
+}
+
 func (ami *ActionableMenuItem) DataChanged() {
+	ami.syncSubItems()
 	if ami.mActionItem.Name != nil {
 		if name, err := ami.mActionItem.Name.Get(); err == nil {
 			ami.mItem.Label = name
@@ -127,6 +179,38 @@ func (ami *ActionableMenuItem) DataChanged() {
 	}
 }
 
+// unbind removes the ActionableMenuItem, its sub-items and the parent and root listeners from the ActionItem bindings
+func (ami *ActionableMenuItem) unbind(parentItem binding.DataListener, rootItem binding.DataListener) {
+	for _, o := range ami.subActionableMenuItems {
+		o.unbind(ami, rootItem)
+	}
+
+	if ami.mActionItem.Name != nil {
+		ami.mActionItem.Name.RemoveListener(ami)
+		if rootItem != nil {
+			ami.mActionItem.Name.RemoveListener(rootItem)
+		}
+	}
+	ami.mActionItem.changed().RemoveListener(ami)
+	if rootItem != nil {
+		ami.mActionItem.changed().RemoveListener(rootItem)
+	}
+	if ami.mActionItem.Disabler != nil {
+		ami.mActionItem.Disabler.RemoveListener(ami)
+	}
+	if ami.mActionItem.Stater != nil {
+		ami.mActionItem.Stater.RemoveListener(ami)
+	}
+	if ami.mActionItem.Hider != nil {
+		if parentItem != nil {
+			ami.mActionItem.Hider.RemoveListener(parentItem)
+		}
+		if rootItem != nil {
+			ami.mActionItem.Hider.RemoveListener(rootItem)
+		}
+	}
+}
+
 func (ami *ActionableMenuItem) getItems() (items []*fyne.MenuItem) {
 	item := ami.mActionItem
 	if item.Hider != nil {
@@ -81,6 +81,25 @@ func NewActionableMenu2(items ...*ActionItem) *ActionableMenu {
 	return am
 }
 
+// unbind removes the ActionableMenu and all its items from the ActionItem bindings, so that it can be discarded
+func (am *ActionableMenu) unbind() {
+	if am.mActionItem.Name != nil {
+		am.mActionItem.Name.RemoveListener(am)
+	}
+	if am.mActionItem.Disabler != nil {
+		am.mActionItem.Disabler.RemoveListener(am)
+	}
+	if am.mActionItem.Stater != nil {
+		am.mActionItem.Stater.RemoveListener(am)
+	}
+	if am.mActionItem.Hider != nil {
+		am.mActionItem.Hider.RemoveListener(am)
+	}
+	if am.mActionableMenuItem != nil {
+		am.mActionableMenuItem.unbind(am, am)
+	}
+}
+
 func (am *ActionableMenu) DataChanged() {
 	if am.mActionItem.Name != nil {
 		if name, err := am.mActionItem.Name.Get(); err == nil {
@@ -89,6 +108,8 @@ func (am *ActionableMenu) DataChanged() {
 	}
 
 	if am.mActionableMenuItem != nil {
+		// sub-actions may have been added or removed since the menu was built
+		am.mActionableMenuItem.syncSubItems()
 		newItems := am.mActionableMenuItem.getItems()
 		if len(newItems) > 0 {
 			if newItems[0].IsSeparator {
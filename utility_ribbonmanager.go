@@ -0,0 +1,281 @@
+package fyneextensions
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
+	"sync"
+)
+
+type ribbonManagerEntry struct {
+	act          Actionable
+	tab          *container.TabItem
+	ribbon       *MainRibbon
+	menu         *ActionableMenu
+	nameListener binding.DataListener
+}
+
+/*
+RibbonManager builds and keeps in sync the user interface elements related to a set of Actionable:
+a container.AppTabs with one MainRibbon tab per Actionable, a fyne.MainMenu with one ActionableMenu
+per Actionable and a tooltip binding shared by all the ribbons.
+
+Actionable can be added or removed at runtime with AddActionable and RemoveActionable, tab names follow
+the name of the Actionable root ActionItem. The selected tab is exposed by ActiveTab, a binding.Int holding
+the index of the active Actionable (-1 if none), which can also be set to select a tab.
+
+An instance of RibbonManager can be created with the factory NewRibbonManager or NewRibbonManagerWithBuilder
+*/
+type RibbonManager struct {
+	Tabs       *container.AppTabs
+	MainMenu   *fyne.MainMenu
+	ToolTipper binding.String
+	ActiveTab  binding.Int
+
+	builder      *RibbonBuilder
+	entries      []*ribbonManagerEntry
+	onTabChanged []func(Actionable)
+	lock         sync.Mutex
+}
+
+/*
+NewRibbonManager is a factory function that creates a RibbonManager for the given Actionable.
+maxSize and blockSize are used to build the ribbons as in BuildTabItemRibbon, while the tooltip binding
+is created by the RibbonManager and available as ToolTipper
+*/
+func NewRibbonManager(maxSize, blockSize float32, acts ...Actionable) *RibbonManager {
+	return NewRibbonManagerWithBuilder(NewRibbonBuilder(maxSize, blockSize, binding.NewString()), acts...)
+}
+
+/*
+NewRibbonManagerWithBuilder works as NewRibbonManager, but ribbons are built by the given RibbonBuilder.
+The RibbonBuilder is copied, later changes to it do not affect the RibbonManager.
+If the RibbonBuilder does not define a ToolTipper, a new binding is created. If the RibbonBuilder Orientation
+is RibbonVertical, tabs are placed with container.TabLocationLeading.
+*/
+func NewRibbonManagerWithBuilder(rb *RibbonBuilder, acts ...Actionable) *RibbonManager {
+	// the RibbonManager works on a copy, so that the builder of the caller is left unchanged
+	nb := *rb
+	rb = &nb
+	if rb.ToolTipper == nil {
+		rb.ToolTipper = binding.NewString()
+	}
+
+	rm := &RibbonManager{
+		Tabs:       container.NewAppTabs(),
+		MainMenu:   fyne.NewMainMenu(),
+		ToolTipper: rb.ToolTipper,
+		ActiveTab:  binding.NewInt(),
+		builder:    rb,
+	}
+	rm.ActiveTab.Set(-1)
+	if rb.Orientation == RibbonVertical {
+		rm.Tabs.SetTabLocation(container.TabLocationLeading)
+	}
+
+	rm.Tabs.OnSelected = func(ti *container.TabItem) {
+		rm.lock.Lock()
+		index := -1
+		var act Actionable
+		for i, o := range rm.entries {
+			if o.tab == ti {
+				index = i
+				act = o.act
+				break
+			}
+		}
+		callbacks := rm.onTabChanged
+		rm.lock.Unlock()
+
+		if index < 0 {
+			return
+		}
+		if cur, _ := rm.ActiveTab.Get(); cur != index {
+			rm.ActiveTab.Set(index)
+		}
+		for _, f := range callbacks {
+			f(act)
+		}
+	}
+	rm.ActiveTab.AddListener(binding.NewDataListener(func() {
+		index, err := rm.ActiveTab.Get()
+		if err != nil {
+			return
+		}
+		rm.lock.Lock()
+		var tab *container.TabItem
+		if index >= 0 && index < len(rm.entries) {
+			tab = rm.entries[index].tab
+		}
+		rm.lock.Unlock()
+
+		if tab != nil && rm.Tabs.Selected() != tab {
+			rm.Tabs.Select(tab)
+		}
+	}))
+
+	rm.AddActionable(acts...)
+
+	return rm
+}
+
+// AddActionable appends a ribbon tab and a main menu entry for each Actionable
+func (rm *RibbonManager) AddActionable(acts ...Actionable) {
+	for _, act := range acts {
+		// the lock is held from the duplicate check to the append, so that concurrent calls add the Actionable once
+		rm.lock.Lock()
+		exists := false
+		for _, o := range rm.entries {
+			if o.act == act {
+				exists = true
+				break
+			}
+		}
+		if exists {
+			rm.lock.Unlock()
+			continue
+		}
+
+		tab, ribbon := BuildTabItemRibbonWithBuilder(act, rm.builder)
+		item := act.GetActions()
+		entry := &ribbonManagerEntry{
+			act:    act,
+			tab:    tab,
+			ribbon: ribbon,
+			menu:   NewActionableMenu(item),
+		}
+		entry.menu.DataChanged()
+		entry.nameListener = binding.NewDataListener(func() {
+			if name, err := item.Name.Get(); err == nil && name != entry.tab.Text {
+				entry.tab.Text = name
+				rm.Tabs.Refresh()
+			}
+		})
+		item.Name.AddListener(entry.nameListener)
+
+		rm.entries = append(rm.entries, entry)
+		rm.lock.Unlock()
+
+		rm.Tabs.Append(tab)
+		rm.MainMenu.Items = append(rm.MainMenu.Items, entry.menu.Menu)
+	}
+	rm.MainMenu.Refresh()
+	rm.syncActiveTab()
+}
+
+// RemoveActionable removes the ribbon tab and the main menu entry of the Actionable
+func (rm *RibbonManager) RemoveActionable(act Actionable) {
+	rm.lock.Lock()
+	index := -1
+	for i, o := range rm.entries {
+		if o.act == act {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		rm.lock.Unlock()
+		return
+	}
+	entry := rm.entries[index]
+	rm.entries = append(rm.entries[:index], rm.entries[index+1:]...)
+	rm.lock.Unlock()
+
+	item := act.GetActions()
+	item.Name.RemoveListener(entry.nameListener)
+	if entry.ribbon != nil {
+		item.Name.RemoveListener(entry.ribbon)
+		entry.ribbon.unbind()
+	}
+	entry.menu.unbind()
+
+	rm.Tabs.Remove(entry.tab)
+	for i, o := range rm.MainMenu.Items {
+		if o == entry.menu.Menu {
+			rm.MainMenu.Items = append(rm.MainMenu.Items[:i], rm.MainMenu.Items[i+1:]...)
+			break
+		}
+	}
+	rm.MainMenu.Refresh()
+	rm.syncActiveTab()
+}
+
+func (rm *RibbonManager) syncActiveTab() {
+	selected := rm.Tabs.Selected()
+	index := -1
+	rm.lock.Lock()
+	for i, o := range rm.entries {
+		if o.tab == selected {
+			index = i
+			break
+		}
+	}
+	rm.lock.Unlock()
+
+	if cur, _ := rm.ActiveTab.Get(); cur != index {
+		rm.ActiveTab.Set(index)
+	}
+}
+
+// Actionables returns the managed Actionable, in tab order. It can be given to NewActionSearchEntry
+func (rm *RibbonManager) Actionables() []Actionable {
+	rm.lock.Lock()
+	defer rm.lock.Unlock()
+
+	acts := make([]Actionable, len(rm.entries))
+	for i, o := range rm.entries {
+		acts[i] = o.act
+	}
+	return acts
+}
+
+// Ribbon returns the MainRibbon built for the Actionable, or nil if it is not managed
+func (rm *RibbonManager) Ribbon(act Actionable) *MainRibbon {
+	rm.lock.Lock()
+	defer rm.lock.Unlock()
+
+	for _, o := range rm.entries {
+		if o.act == act {
+			return o.ribbon
+		}
+	}
+	return nil
+}
+
+// ActiveActionable returns the Actionable of the selected tab, or nil if none
+func (rm *RibbonManager) ActiveActionable() Actionable {
+	index, _ := rm.ActiveTab.Get()
+
+	rm.lock.Lock()
+	defer rm.lock.Unlock()
+
+	if index >= 0 && index < len(rm.entries) {
+		return rm.entries[index].act
+	}
+	return nil
+}
+
+// SelectActionable selects the tab of the Actionable
+func (rm *RibbonManager) SelectActionable(act Actionable) {
+	rm.lock.Lock()
+	var tab *container.TabItem
+	for _, o := range rm.entries {
+		if o.act == act {
+			tab = o.tab
+			break
+		}
+	}
+	rm.lock.Unlock()
+
+	if tab != nil {
+		rm.Tabs.Select(tab)
+	}
+}
+
+// AddOnTabChanged registers a callback invoked with the Actionable of the tab every time a new tab is selected
+func (rm *RibbonManager) AddOnTabChanged(f func(Actionable)) {
+	rm.lock.Lock()
+	defer rm.lock.Unlock()
+
+	rm.onTabChanged = append(rm.onTabChanged, f)
+}
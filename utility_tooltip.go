@@ -0,0 +1,292 @@
+package fyneextensions
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"image/color"
+	"sync"
+	"time"
+)
+
+var (
+	toolTipManagers     = map[fyne.Canvas]*ToolTipManager{}
+	toolTipManagersLock sync.Mutex
+)
+
+/*
+ToolTipManager shows tooltips for the objects of a fyne.Canvas. There is a single ToolTipManager per canvas,
+obtained with ToolTipManagerForCanvas, and a single tooltip visible at a time.
+
+Tooltips are drawn in a layer on top of the canvas content, which the application adds with AddToolTipLayer when
+setting the window content. The layer holds plain canvas objects only, so it never receives the pointer or
+keyboard events: the objects below it work as if the tooltip was not there. Canvases without the layer show
+no tooltip.
+
+Any widget can show a tooltip by forwarding its hover events to MouseIn (rich content) or MouseInText (plain text),
+MouseMoved and MouseOut. The tooltip is shown after the pointer rests on the object for ShowDelay, it is placed
+near the pointer and always inside the canvas, it fades in in FadeDuration (when animations are enabled) and it is
+hidden after HideDelay, if greater than zero. The manager keeps the content provider of the object under the
+pointer only, until the pointer leaves it.
+
+The ToolTipManager of a canvas is released when its window is closed (it is removed the next time a manager is
+requested), or explicitly with ReleaseToolTipManager.
+*/
+type ToolTipManager struct {
+	ShowDelay    time.Duration
+	HideDelay    time.Duration
+	FadeDuration time.Duration
+
+	mCanvas   fyne.Canvas
+	owner     fyne.CanvasObject
+	provider  func() fyne.CanvasObject
+	pointer   fyne.Position
+	isShown   bool
+	showTimer *time.Timer
+	hideTimer *time.Timer
+	lock      sync.Mutex
+
+	mLayer      *fyne.Container
+	mBox        *fyne.Container
+	mContent    *fyne.Container
+	mBackground *canvas.Rectangle
+	mCover      *canvas.Rectangle
+	fadeAnim    *fyne.Animation
+	hasLayer    bool
+}
+
+// ToolTipManagerForCanvas returns the ToolTipManager of the canvas, creating it the first time
+func ToolTipManagerForCanvas(mCanvas fyne.Canvas) *ToolTipManager {
+	toolTipManagersLock.Lock()
+	defer toolTipManagersLock.Unlock()
+
+	if m, ok := toolTipManagers[mCanvas]; ok {
+		return m
+	}
+	releaseClosedToolTipManagers()
+
+	m := &ToolTipManager{
+		ShowDelay:    700 * time.Millisecond,
+		FadeDuration: canvas.DurationShort,
+
+		mCanvas:     mCanvas,
+		mContent:    container.NewStack(),
+		mBackground: canvas.NewRectangle(theme.OverlayBackgroundColor()),
+		mCover:      canvas.NewRectangle(color.Transparent),
+	}
+	m.mBackground.StrokeColor = theme.ShadowColor()
+	m.mBackground.StrokeWidth = 1.
+	m.mBox = container.NewStack(m.mBackground, container.NewPadded(m.mContent), m.mCover)
+	m.mBox.Hide()
+	m.mLayer = container.NewWithoutLayout(m.mBox)
+
+	m.fadeAnim = fyne.NewAnimation(m.FadeDuration, func(done float32) {
+		r, g, b, _ := ToNRGBA(m.mBackground.FillColor)
+		m.mCover.FillColor = &color.NRGBA{R: uint8(r), G: uint8(g), B: uint8(b), A: uint8(255. * (1. - done))}
+		m.mCover.Refresh()
+	})
+
+	// the timers run the tooltip changes on the event goroutine of the window, as the pointer events
+	m.showTimer = time.AfterFunc(time.Hour, func() {
+		runOnEventQueue(mCanvas, m.show)
+	})
+	m.showTimer.Stop()
+	m.hideTimer = time.AfterFunc(time.Hour, func() {
+		runOnEventQueue(mCanvas, m.Hide)
+	})
+	m.hideTimer.Stop()
+
+	toolTipManagers[mCanvas] = m
+	return m
+}
+
+/*
+AddToolTipLayer returns content with the tooltip layer of mCanvas on top of it. It is meant to be set as the
+content of the window of mCanvas, e.g. w.SetContent(AddToolTipLayer(content, w.Canvas()))
+*/
+func AddToolTipLayer(content fyne.CanvasObject, mCanvas fyne.Canvas) fyne.CanvasObject {
+	m := ToolTipManagerForCanvas(mCanvas)
+	m.Hide()
+
+	m.lock.Lock()
+	m.hasLayer = true
+	m.lock.Unlock()
+
+	return container.NewStack(content, m.mLayer)
+}
+
+// ReleaseToolTipManager hides the tooltip of the canvas and releases its ToolTipManager, e.g. when its window is closed
+func ReleaseToolTipManager(mCanvas fyne.Canvas) {
+	toolTipManagersLock.Lock()
+	m, ok := toolTipManagers[mCanvas]
+	delete(toolTipManagers, mCanvas)
+	toolTipManagersLock.Unlock()
+
+	if ok {
+		m.Hide()
+		m.lock.Lock()
+		m.owner, m.provider = nil, nil
+		m.lock.Unlock()
+	}
+}
+
+// releaseClosedToolTipManagers removes the managers of the canvases whose window has been closed. The lock must be held
+func releaseClosedToolTipManagers() {
+	if fyne.CurrentApp() == nil {
+		return
+	}
+	open := map[fyne.Canvas]bool{}
+	for _, w := range fyne.CurrentApp().Driver().AllWindows() {
+		open[w.Canvas()] = true
+	}
+	for c, m := range toolTipManagers {
+		if !open[c] {
+			m.showTimer.Stop()
+			m.hideTimer.Stop()
+			delete(toolTipManagers, c)
+		}
+	}
+}
+
+/*
+MouseIn must be called when the pointer enters obj, with the pointer absolute position and the function returning
+the tooltip content of obj. A nil content means no tooltip
+*/
+func (m *ToolTipManager) MouseIn(obj fyne.CanvasObject, absPos fyne.Position, content func() fyne.CanvasObject) {
+	m.lock.Lock()
+	m.owner = obj
+	m.provider = content
+	m.pointer = absPos
+	m.lock.Unlock()
+
+	m.Hide()
+	m.showTimer.Reset(m.ShowDelay)
+}
+
+// MouseInText is as MouseIn, with a function returning the tooltip text of obj. An empty text means no tooltip
+func (m *ToolTipManager) MouseInText(obj fyne.CanvasObject, absPos fyne.Position, text func() string) {
+	m.MouseIn(obj, absPos, func() fyne.CanvasObject {
+		if tx := text(); tx != "" {
+			return widget.NewLabel(tx)
+		}
+		return nil
+	})
+}
+
+// MouseMoved must be called when the pointer moves over obj. The tooltip delay restarts until it is shown
+func (m *ToolTipManager) MouseMoved(obj fyne.CanvasObject, absPos fyne.Position) {
+	m.lock.Lock()
+	if m.owner != obj {
+		m.lock.Unlock()
+		return
+	}
+	m.pointer = absPos
+	isShown := m.isShown
+	m.lock.Unlock()
+
+	if !isShown {
+		m.showTimer.Reset(m.ShowDelay)
+	}
+}
+
+// MouseOut must be called when the pointer leaves obj, or when obj does not need its tooltip anymore
+func (m *ToolTipManager) MouseOut(obj fyne.CanvasObject) {
+	m.lock.Lock()
+	if m.owner != obj {
+		m.lock.Unlock()
+		return
+	}
+	m.owner, m.provider = nil, nil
+	m.lock.Unlock()
+
+	m.Hide()
+}
+
+// Hide hides the visible tooltip and cancels the pending one. The tooltip is shown again when the pointer moves
+func (m *ToolTipManager) Hide() {
+	m.showTimer.Stop()
+	m.hideTimer.Stop()
+
+	m.lock.Lock()
+	wasShown := m.isShown
+	m.isShown = false
+	m.lock.Unlock()
+
+	if wasShown {
+		m.fadeAnim.Stop()
+		m.mBox.Hide()
+		m.mContent.Objects = nil
+		m.mLayer.Refresh()
+	}
+}
+
+func (m *ToolTipManager) show() {
+	m.lock.Lock()
+	provider, pointer, hasLayer := m.provider, m.pointer, m.hasLayer
+	m.lock.Unlock()
+	if provider == nil || !hasLayer {
+		return
+	}
+	content := provider()
+	if content == nil {
+		return
+	}
+
+	m.lock.Lock()
+	if m.provider == nil || m.isShown {
+		// the pointer left the object while the content was created
+		m.lock.Unlock()
+		return
+	}
+	m.isShown = true
+	m.lock.Unlock()
+
+	m.mBackground.FillColor = theme.OverlayBackgroundColor()
+	m.mBackground.StrokeColor = theme.ShadowColor()
+	m.mContent.Objects = []fyne.CanvasObject{content}
+	m.mContent.Refresh()
+
+	size := m.mBox.MinSize()
+	m.mBox.Resize(size)
+	m.mBox.Move(m.placement(pointer, size))
+
+	if fyne.CurrentApp().Settings().ShowAnimations() && m.FadeDuration > 0 {
+		m.fadeAnim.Duration = m.FadeDuration
+		m.mCover.FillColor = m.mBackground.FillColor
+		m.fadeAnim.Start()
+	} else {
+		m.mCover.FillColor = color.Transparent
+	}
+	m.mBox.Show()
+	m.mLayer.Refresh()
+
+	if m.HideDelay > 0 {
+		m.hideTimer.Reset(m.HideDelay)
+	}
+}
+
+// placement returns the tooltip position in the layer coordinates: below the pointer, or above it
+// when there is no room below, and always inside the layer
+func (m *ToolTipManager) placement(pointer fyne.Position, size fyne.Size) fyne.Position {
+	lSize := m.mLayer.Size()
+	pointer = pointer.Subtract(fyne.CurrentApp().Driver().AbsolutePositionForObject(m.mLayer))
+	offset := theme.IconInlineSize()
+
+	pos := fyne.NewPos(pointer.X, pointer.Y+offset)
+	if pos.Y+size.Height > lSize.Height {
+		pos.Y = pointer.Y - size.Height - theme.Padding()
+	}
+	if pos.X+size.Width > lSize.Width {
+		pos.X = lSize.Width - size.Width
+	}
+	if pos.X < 0 {
+		pos.X = 0
+	}
+	if pos.Y < 0 {
+		pos.Y = 0
+	}
+
+	return pos
+}
@@ -0,0 +1,453 @@
+package fyneextensions
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"strings"
+)
+
+// ActionSearchPathSeparator is the separator used to display, and to store in the recently used list, the path of an ActionItem
+const ActionSearchPathSeparator = " > "
+
+type actionSearchResult struct {
+	item     *ActionItem
+	name     string
+	path     string
+	disabled bool
+}
+
+func (r *actionSearchResult) key() string {
+	if r.path == "" {
+		return r.name
+	}
+	return r.path + ActionSearchPathSeparator + r.name
+}
+
+func collectActionSearchResults(item *ActionItem, path []string, parentDisabled bool, results []*actionSearchResult) []*actionSearchResult {
+	if item.Hider != nil {
+		if hidden, err := item.Hider.Get(); err == nil && hidden {
+			return results
+		}
+	}
+	disabled := parentDisabled
+	if item.Disabler != nil {
+		if d, err := item.Disabler.Get(); err == nil && d {
+			disabled = true
+		}
+	}
+	name := ""
+	if item.Name != nil {
+		name, _ = item.Name.Get()
+	}
+
+	if item.Triggered != nil {
+		results = append(results, &actionSearchResult{
+			item:     item,
+			name:     name,
+			path:     strings.Join(path, ActionSearchPathSeparator),
+			disabled: disabled,
+		})
+	}
+
+	subPath := append(append([]string{}, path...), name)
+	for _, o := range item.SubActions {
+		results = collectActionSearchResults(o, subPath, disabled, results)
+	}
+	return results
+}
+
+/*
+ActionSearchEntry is a Fyne compatible widget which provides a "Tell me" search box for ActionItem.
+
+It queries all the ActionItem of the Actionable returned by the function given to NewActionSearchEntry
+(e.g. RibbonManager.Actionables), lists the matches in a dropdown with their icon and path, and executes the selected one.
+Hidden actions are not listed, disabled actions are listed but cannot be executed.
+When the entry is empty, the recently used actions are listed instead. The recently used list is
+persisted in the application preferences under PreferenceKey.
+
+NewRibbonWithActionSearch can be used to lay out the entry alongside the tabs of a container.AppTabs.
+An instance of ActionSearchEntry can be created with the factory NewActionSearchEntry
+*/
+type ActionSearchEntry struct {
+	widget.Entry
+
+	mCanvas     fyne.Canvas
+	actionables func() []Actionable
+	results     []*actionSearchResult
+	mResults    *actionSearchResults
+	mPopUp      *widget.PopUp
+
+	isExecuting  bool
+	isRefocusing bool
+
+	MaxResults    int
+	MaxRecent     int
+	PreferenceKey string
+}
+
+/*
+NewActionSearchEntry is a factory function that creates a new ActionSearchEntry operating on mCanvas.
+actionables returns the Actionable to search, it is called at every search so that the set can change at runtime
+*/
+func NewActionSearchEntry(mCanvas fyne.Canvas, actionables func() []Actionable) *ActionSearchEntry {
+	t := &ActionSearchEntry{
+		mCanvas:       mCanvas,
+		actionables:   actionables,
+		MaxResults:    15,
+		MaxRecent:     10,
+		PreferenceKey: "fyneextensions.actionsearch.recent",
+	}
+	t.ExtendBaseWidget(t)
+	t.SetPlaceHolder("Tell me what you want to do")
+	t.ActionItem = widget.NewIcon(theme.SearchIcon())
+
+	t.mResults = newActionSearchResults(t)
+	t.mPopUp = widget.NewPopUp(t.mResults, mCanvas)
+	t.mPopUp.Hide()
+
+	t.OnChanged = func(string) {
+		if !t.isExecuting {
+			t.updateResults()
+		}
+	}
+
+	return t
+}
+
+func (t *ActionSearchEntry) FocusGained() {
+	t.Entry.FocusGained()
+	if t.Text == "" && !t.mPopUp.Visible() && !t.isRefocusing {
+		t.updateResults()
+	}
+}
+
+// AcceptsTab lets Tab move to the results while they are shown
+func (t *ActionSearchEntry) AcceptsTab() bool {
+	return t.mPopUp.Visible()
+}
+
+func (t *ActionSearchEntry) TypedKey(key *fyne.KeyEvent) {
+	switch key.Name {
+	case fyne.KeyDown, fyne.KeyTab:
+		if t.mPopUp.Visible() {
+			t.mCanvas.Focus(t.mResults)
+			t.mResults.TypedKey(key)
+		} else if key.Name == fyne.KeyDown {
+			t.updateResults()
+		}
+	case fyne.KeyEscape:
+		t.hideResults()
+	default:
+		t.Entry.TypedKey(key)
+	}
+}
+
+func (t *ActionSearchEntry) searchActions(text string) (results []*actionSearchResult) {
+	var all []*actionSearchResult
+	if t.actionables == nil {
+		return
+	}
+	for _, o := range t.actionables() {
+		if item := o.GetActions(); item != nil {
+			all = collectActionSearchResults(item, nil, false, all)
+		}
+	}
+
+	if text == "" {
+		for _, k := range t.recentKeys() {
+			for _, o := range all {
+				if o.key() == k {
+					results = append(results, o)
+					break
+				}
+			}
+		}
+		return
+	}
+
+	text = strings.ToUpper(text)
+	for _, o := range all {
+		if strings.Contains(strings.ToUpper(o.name), text) || strings.Contains(strings.ToUpper(o.path), text) {
+			results = append(results, o)
+			if t.MaxResults > 0 && len(results) >= t.MaxResults {
+				break
+			}
+		}
+	}
+	return
+}
+
+func (t *ActionSearchEntry) updateResults() {
+	t.results = t.searchActions(t.Text)
+	t.mResults.reset()
+
+	if len(t.results) == 0 || t.mCanvas == nil {
+		t.hideResults()
+		return
+	}
+
+	width := t.Size().Width
+	if minWidth := t.mResults.MinSize().Width; minWidth > width {
+		width = minWidth
+	}
+	height := t.mResults.rowHeight() * float32(len(t.results))
+	t.mPopUp.Resize(fyne.NewSize(width, height))
+	if !t.mPopUp.Visible() {
+		t.mPopUp.ShowAtRelativePosition(fyne.NewPos(0., t.Size().Height), t)
+		// the popup overlay receives the keyboard events: until KeyDown or Tab the results pass them to the entry
+		t.mCanvas.Focus(t.mResults)
+	}
+}
+
+func (t *ActionSearchEntry) hideResults() {
+	if t.mPopUp.Visible() {
+		t.mPopUp.Hide()
+		if t.mCanvas != nil {
+			t.isRefocusing = true
+			t.mCanvas.Focus(t)
+			t.isRefocusing = false
+		}
+	}
+}
+
+func (t *ActionSearchEntry) execute(id int) {
+	if id < 0 || id >= len(t.results) {
+		return
+	}
+	res := t.results[id]
+	if res.disabled {
+		return
+	}
+
+	t.addRecent(res.key())
+	t.hideResults()
+	t.isExecuting = true
+	t.SetText("")
+	t.isExecuting = false
+
+	state := 0
+	if res.item.Stater != nil {
+		state, _ = res.item.Stater.Get()
+	}
+	res.item.Triggered(state)
+}
+
+func (t *ActionSearchEntry) recentKeys() []string {
+	if fyne.CurrentApp() == nil || t.PreferenceKey == "" {
+		return nil
+	}
+	return fyne.CurrentApp().Preferences().StringList(t.PreferenceKey)
+}
+
+func (t *ActionSearchEntry) addRecent(key string) {
+	if fyne.CurrentApp() == nil || t.PreferenceKey == "" {
+		return
+	}
+	recent := []string{key}
+	for _, o := range t.recentKeys() {
+		if o != key {
+			recent = append(recent, o)
+		}
+	}
+	if t.MaxRecent > 0 && len(recent) > t.MaxRecent {
+		recent = recent[:t.MaxRecent]
+	}
+	fyne.CurrentApp().Preferences().SetStringList(t.PreferenceKey, recent)
+}
+
+// actionSearchResults is the focusable list of results shown in the ActionSearchEntry popup.
+// It forwards the typed keys and shortcuts to the entry, and handles the keyboard navigation once started with
+// KeyDown or Tab.
+type actionSearchResults struct {
+	widget.BaseWidget
+
+	entry      *ActionSearchEntry
+	mList      *widget.List
+	selected   int
+	navigating bool
+	isActive   bool
+}
+
+func newActionSearchResults(entry *ActionSearchEntry) *actionSearchResults {
+	t := &actionSearchResults{
+		entry:    entry,
+		selected: -1,
+	}
+	t.ExtendBaseWidget(t)
+
+	t.mList = widget.NewList(
+		func() int {
+			return len(t.entry.results)
+		},
+		func() fyne.CanvasObject {
+			pathLbl := widget.NewLabel("")
+			pathLbl.Importance = widget.LowImportance
+			return container.NewHBox(widget.NewIcon(nil), widget.NewLabel(""), pathLbl)
+		},
+		func(id widget.ListItemID, o fyne.CanvasObject) {
+			if id < 0 || id >= len(t.entry.results) {
+				return
+			}
+			res := t.entry.results[id]
+			row := o.(*fyne.Container)
+			icon := row.Objects[0].(*widget.Icon)
+			nameLbl := row.Objects[1].(*widget.Label)
+			pathLbl := row.Objects[2].(*widget.Label)
+
+			if len(res.item.Resources) > 0 {
+				state := 0
+				if res.item.Stater != nil {
+					state, _ = res.item.Stater.Get()
+				}
+				if state >= len(res.item.Resources) {
+					state = 0
+				}
+				icon.SetResource(res.item.Resources[state])
+			} else {
+				icon.SetResource(nil)
+			}
+
+			if res.disabled {
+				nameLbl.Importance = widget.LowImportance
+			} else {
+				nameLbl.Importance = widget.MediumImportance
+			}
+			nameLbl.SetText(res.name)
+			pathLbl.SetText(res.path)
+		},
+	)
+	t.mList.OnSelected = func(id widget.ListItemID) {
+		if t.navigating {
+			return
+		}
+		t.entry.execute(id)
+	}
+
+	return t
+}
+
+func (t *actionSearchResults) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(t.mList)
+}
+
+func (t *actionSearchResults) MinSize() fyne.Size {
+	return fyne.NewSize(t.mList.MinSize().Width, t.rowHeight())
+}
+
+func (t *actionSearchResults) rowHeight() float32 {
+	return t.mList.CreateItem().MinSize().Height + theme.SeparatorThicknessSize()
+}
+
+func (t *actionSearchResults) reset() {
+	t.selected = -1
+	t.isActive = false
+	t.navigating = true
+	t.mList.UnselectAll()
+	t.navigating = false
+	t.mList.Refresh()
+}
+
+func (t *actionSearchResults) moveSelection(delta int) {
+	if len(t.entry.results) == 0 {
+		return
+	}
+	t.selected += delta
+	if t.selected < 0 {
+		t.selected = 0
+	}
+	if t.selected >= len(t.entry.results) {
+		t.selected = len(t.entry.results) - 1
+	}
+	t.navigating = true
+	t.mList.Select(t.selected)
+	t.navigating = false
+}
+
+func (t *actionSearchResults) FocusGained() {}
+
+func (t *actionSearchResults) FocusLost() {}
+
+func (t *actionSearchResults) TypedRune(r rune) {
+	t.entry.TypedRune(r)
+}
+
+func (t *actionSearchResults) TypedShortcut(shortcut fyne.Shortcut) {
+	t.entry.TypedShortcut(shortcut)
+}
+
+// AcceptsTab lets Tab move the selection instead of the focus
+func (t *actionSearchResults) AcceptsTab() bool {
+	return true
+}
+
+func (t *actionSearchResults) TypedKey(key *fyne.KeyEvent) {
+	switch key.Name {
+	case fyne.KeyDown, fyne.KeyTab:
+		t.isActive = true
+		t.moveSelection(1)
+	case fyne.KeyUp:
+		if !t.isActive {
+			t.entry.Entry.TypedKey(key)
+			return
+		}
+		if t.selected <= 0 {
+			// back to the entry
+			t.reset()
+			return
+		}
+		t.moveSelection(-1)
+	case fyne.KeyReturn, fyne.KeyEnter:
+		if t.selected < 0 {
+			t.selected = 0
+		}
+		t.entry.execute(t.selected)
+	case fyne.KeyEscape:
+		t.entry.hideResults()
+	default:
+		t.entry.Entry.TypedKey(key)
+	}
+}
+
+type ribbonSearchLayout struct {
+	searchWidth float32
+}
+
+func (d *ribbonSearchLayout) MinSize(objects []fyne.CanvasObject) fyne.Size {
+	if len(objects) != 2 {
+		return fyne.NewSize(0., 0.)
+	}
+	return objects[0].MinSize()
+}
+
+func (d *ribbonSearchLayout) Layout(objects []fyne.CanvasObject, containerSize fyne.Size) {
+	if len(objects) != 2 {
+		for _, o := range objects {
+			o.Resize(fyne.NewSize(0., 0.))
+			o.Move(fyne.NewPos(0., 0.))
+		}
+		return
+	}
+	objects[0].Resize(containerSize)
+	objects[0].Move(fyne.NewPos(0., 0.))
+
+	sSize := objects[1].MinSize()
+	if d.searchWidth > sSize.Width {
+		sSize.Width = d.searchWidth
+	}
+	if sSize.Width > containerSize.Width/2. {
+		sSize.Width = containerSize.Width / 2.
+	}
+	objects[1].Resize(sSize)
+	objects[1].Move(fyne.NewPos(containerSize.Width-sSize.Width, 0.))
+}
+
+/*
+NewRibbonWithActionSearch returns a container laying out the ActionSearchEntry on the top-right corner
+of the container.AppTabs, alongside the tabs. searchWidth defines the preferred width of the entry,
+which will never exceed half of the container width
+*/
+func NewRibbonWithActionSearch(tabs *container.AppTabs, search *ActionSearchEntry, searchWidth float32) *fyne.Container {
+	return container.New(&ribbonSearchLayout{searchWidth: searchWidth}, tabs, search)
+}
@@ -0,0 +1,394 @@
+package fyneextensions
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+/*
+Backstage is a Fyne compatible widget which provides a full-window "File" view for applications built on MainRibbon.
+While open it covers the whole canvas, hiding the ribbon, and shows a navigation list on the left
+(e.g. Info, Open, Save as, Print, Options) and the content of the selected entry on the right.
+
+It is based on ActionItem: leaves of the ActionItem tree with a Content function are listed in the navigation and
+show the returned object when selected, leaves with a Triggered function only are executed and close the Backstage.
+Hidden and disabled items are honoured.
+
+It can be opened from a special first tab of a container.AppTabs with BuildTabItemBackstage, or via Open.
+It is closed with the Escape key, the back button or Close. The navigation list takes the keyboard focus when the
+Backstage is opened, and Escape closes it while the list or nothing is focused.
+The Backstage listens to the bindings of its ActionItem tree only while open.
+*/
+type Backstage struct {
+	widget.BaseWidget
+
+	mAction *ActionItem
+	mCanvas fyne.Canvas
+
+	navItems []*ActionItem
+	selected *ActionItem
+	isOpen   bool
+
+	mBackground    *canvas.Rectangle
+	mNavBackground *canvas.Rectangle
+	mBackButton    *FlexButton
+	mNavList       *backstageNavList
+	mContent       *fyne.Container
+	mContainer     *fyne.Container
+
+	prevOnTypedKey func(*fyne.KeyEvent)
+	listened       []binding.DataItem
+
+	// OnClosed is called every time the Backstage is closed
+	OnClosed func()
+}
+
+/*
+NewBackstage is the factory function for Backstage object
+
+it requires the following inputs:
+- item: the root ActionItem. Its sub-actions define the navigation list.
+- mCanvas: the fyne.Canvas the Backstage will cover when open.
+*/
+func NewBackstage(item *ActionItem, mCanvas fyne.Canvas) *Backstage {
+	bs := &Backstage{
+		mAction:        item,
+		mCanvas:        mCanvas,
+		mBackground:    canvas.NewRectangle(theme.BackgroundColor()),
+		mNavBackground: canvas.NewRectangle(theme.ButtonColor()),
+		mContent:       container.NewStack(),
+	}
+	bs.ExtendBaseWidget(bs)
+
+	bs.mBackButton = NewFlexButton("", []fyne.Resource{theme.NavigateBackIcon()}, true, false, true, false, false, 40., 20., mCanvas, func(int) {
+		bs.Close()
+	}, nil, nil, nil, nil, nil)
+
+	bs.mNavList = &backstageNavList{bs: bs}
+	bs.mNavList.Length = func() int {
+		return len(bs.navItems)
+	}
+	bs.mNavList.CreateItem = func() fyne.CanvasObject {
+		return container.NewHBox(widget.NewIcon(nil), widget.NewLabel(""))
+	}
+	bs.mNavList.UpdateItem = func(id widget.ListItemID, o fyne.CanvasObject) {
+		if id < 0 || id >= len(bs.navItems) {
+			return
+		}
+		itm := bs.navItems[id]
+		row := o.(*fyne.Container)
+		icon := row.Objects[0].(*widget.Icon)
+		lbl := row.Objects[1].(*widget.Label)
+
+		if len(itm.Resources) > 0 {
+			state := 0
+			if itm.Stater != nil {
+				state, _ = itm.Stater.Get()
+			}
+			if state >= len(itm.Resources) {
+				state = 0
+			}
+			icon.SetResource(itm.Resources[state])
+		} else {
+			icon.SetResource(nil)
+		}
+
+		name := ""
+		if itm.Name != nil {
+			name, _ = itm.Name.Get()
+		}
+		lbl.SetText(name)
+		if itm.Disabler != nil {
+			if disabled, _ := itm.Disabler.Get(); disabled {
+				lbl.Importance = widget.LowImportance
+			} else {
+				lbl.Importance = widget.MediumImportance
+			}
+			lbl.Refresh()
+		}
+	}
+	bs.mNavList.ExtendBaseWidget(bs.mNavList)
+	bs.mNavList.OnSelected = func(id widget.ListItemID) {
+		if id < 0 || id >= len(bs.navItems) {
+			return
+		}
+		bs.selectItem(bs.navItems[id])
+	}
+
+	navPanel := container.NewStack(
+		bs.mNavBackground,
+		container.NewBorder(bs.mBackButton, nil, nil, nil, bs.mNavList),
+	)
+	bs.mContainer = container.NewStack(
+		bs.mBackground,
+		container.NewBorder(nil, nil, navPanel, nil, container.NewPadded(bs.mContent)),
+	)
+
+	bs.DataChanged()
+
+	return bs
+}
+
+// listenItem adds the Backstage to the listeners of the bindings of the sub-actions of item, recursively
+func (bs *Backstage) listenItem(item *ActionItem) {
+	for _, o := range item.SubActions {
+		for _, d := range []binding.DataItem{o.Name, o.Disabler, o.Hider, o.Stater} {
+			if d == nil {
+				continue
+			}
+			d.AddListener(bs)
+			bs.listened = append(bs.listened, d)
+		}
+		bs.listenItem(o)
+	}
+}
+
+// unlisten removes the Backstage from the listeners added by listenItem
+func (bs *Backstage) unlisten() {
+	for _, d := range bs.listened {
+		d.RemoveListener(bs)
+	}
+	bs.listened = nil
+}
+
+func collectBackstageItems(item *ActionItem) (items []*ActionItem) {
+	if item.Hider != nil {
+		if hidden, err := item.Hider.Get(); err == nil && hidden {
+			return nil
+		}
+	}
+	if item.Content != nil || (item.Triggered != nil && len(item.SubActions) == 0) {
+		return []*ActionItem{item}
+	}
+	for _, o := range item.SubActions {
+		items = append(items, collectBackstageItems(o)...)
+	}
+	return
+}
+
+func (bs *Backstage) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(bs.mContainer)
+}
+
+func (bs *Backstage) MinSize() fyne.Size {
+	return bs.mContainer.MinSize()
+}
+
+func (bs *Backstage) Refresh() {
+	bs.mBackground.FillColor = theme.BackgroundColor()
+	bs.mBackground.Refresh()
+	bs.mNavBackground.FillColor = theme.ButtonColor()
+	bs.mNavBackground.Refresh()
+
+	bs.mNavList.Refresh()
+	bs.mContent.Refresh()
+}
+
+func (bs *Backstage) DataChanged() {
+	bs.navItems = nil
+	for _, o := range bs.mAction.SubActions {
+		bs.navItems = append(bs.navItems, collectBackstageItems(o)...)
+	}
+
+	found := false
+	for i, o := range bs.navItems {
+		if o == bs.selected {
+			bs.mNavList.Select(i)
+			found = true
+			break
+		}
+	}
+	if !found {
+		bs.selected = nil
+		bs.mNavList.UnselectAll()
+		bs.mContent.RemoveAll()
+	}
+
+	bs.Refresh()
+}
+
+func (bs *Backstage) selectItem(item *ActionItem) {
+	if item == bs.selected {
+		return
+	}
+
+	if item.Disabler != nil {
+		if disabled, _ := item.Disabler.Get(); disabled {
+			bs.restoreSelection()
+			return
+		}
+	}
+
+	if item.Content == nil {
+		bs.restoreSelection()
+		if item.Triggered != nil {
+			state := 0
+			if item.Stater != nil {
+				state, _ = item.Stater.Get()
+			}
+			item.Triggered(state)
+		}
+		bs.Close()
+		return
+	}
+
+	bs.selected = item
+	bs.mContent.RemoveAll()
+	if obj := item.Content(); obj != nil {
+		bs.mContent.Add(obj)
+	}
+	bs.mContent.Refresh()
+}
+
+func (bs *Backstage) restoreSelection() {
+	for i, o := range bs.navItems {
+		if o == bs.selected {
+			bs.mNavList.Select(i)
+			return
+		}
+	}
+	bs.mNavList.UnselectAll()
+}
+
+// IsOpen returns true if the Backstage is currently covering the canvas
+func (bs *Backstage) IsOpen() bool {
+	return bs.isOpen
+}
+
+/*
+Open shows the Backstage on top of the whole canvas. If no entry is selected, the first enabled entry
+with a Content function is selected.
+*/
+func (bs *Backstage) Open() {
+	if bs.isOpen || bs.mCanvas == nil {
+		return
+	}
+	bs.isOpen = true
+	bs.listenItem(bs.mAction)
+	bs.DataChanged()
+
+	if bs.selected == nil {
+		for i, o := range bs.navItems {
+			if o.Content == nil {
+				continue
+			}
+			if o.Disabler != nil {
+				if disabled, _ := o.Disabler.Get(); disabled {
+					continue
+				}
+			}
+			bs.mNavList.Select(i)
+			break
+		}
+	}
+
+	bs.prevOnTypedKey = bs.mCanvas.OnTypedKey()
+	bs.mCanvas.SetOnTypedKey(func(ev *fyne.KeyEvent) {
+		if ev.Name == fyne.KeyEscape {
+			bs.Close()
+			return
+		}
+		if bs.prevOnTypedKey != nil {
+			bs.prevOnTypedKey(ev)
+		}
+	})
+
+	bs.mCanvas.Overlays().Add(bs)
+	bs.Resize(bs.mCanvas.Size())
+	bs.Move(fyne.NewPos(0., 0.))
+	bs.Refresh()
+	bs.mCanvas.Focus(bs.mNavList)
+}
+
+// Close removes the Backstage from the canvas, showing again the application content
+func (bs *Backstage) Close() {
+	if !bs.isOpen {
+		return
+	}
+	bs.isOpen = false
+	bs.unlisten()
+
+	bs.mCanvas.SetOnTypedKey(bs.prevOnTypedKey)
+	bs.prevOnTypedKey = nil
+	bs.mCanvas.Overlays().Remove(bs)
+
+	if bs.OnClosed != nil {
+		bs.OnClosed()
+	}
+}
+
+/*
+BuildTabItemBackstage is a function that constructs a `container.TabItem` and a `Backstage` from an actionable item.
+The TabItem is inserted as first tab of tabs: selecting it opens the Backstage while the previously
+selected tab is kept selected.
+
+Notice that the function wraps tabs.OnSelected, so any OnSelected callback should be defined before calling it,
+and that it should be called after the ribbon tabs are appended, so that one of them stays selected.
+*/
+func BuildTabItemBackstage(act Actionable, tabs *container.AppTabs) (*container.TabItem, *Backstage) {
+	item := act.GetActions()
+	bs := NewBackstage(item, act.GetCanvas())
+
+	name := ""
+	if item.Name != nil {
+		name, _ = item.Name.Get()
+	}
+	retV := container.NewTabItem(name, container.NewStack())
+	if item.Name != nil {
+		item.Name.AddListener(binding.NewDataListener(func() {
+			if n, err := item.Name.Get(); err == nil && n != retV.Text {
+				retV.Text = n
+				tabs.Refresh()
+			}
+		}))
+	}
+
+	lastTab := tabs.Selected()
+	tabs.SetItems(append([]*container.TabItem{retV}, tabs.Items...))
+	if lastTab != nil {
+		tabs.Select(lastTab)
+	}
+
+	prevOnSelected := tabs.OnSelected
+	isRestoring := false
+	tabs.OnSelected = func(ti *container.TabItem) {
+		if isRestoring {
+			return
+		}
+		if ti == retV {
+			if lastTab != nil {
+				// the previous tab is selected again without notifying the application
+				isRestoring = true
+				tabs.Select(lastTab)
+				isRestoring = false
+			}
+			bs.Open()
+			return
+		}
+		lastTab = ti
+		if prevOnSelected != nil {
+			prevOnSelected(ti)
+		}
+	}
+
+	return retV, bs
+}
+
+// backstageNavList is the navigation list of a Backstage, closing it when Escape is typed while focused
+type backstageNavList struct {
+	widget.List
+
+	bs *Backstage
+}
+
+func (l *backstageNavList) TypedKey(key *fyne.KeyEvent) {
+	if key.Name == fyne.KeyEscape {
+		l.bs.Close()
+		return
+	}
+	l.List.TypedKey(key)
+}
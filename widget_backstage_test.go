@@ -0,0 +1,122 @@
+package fyneextensions
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+)
+
+type testActionable struct {
+	item    *ActionItem
+	mCanvas fyne.Canvas
+}
+
+func (a *testActionable) GetActions() *ActionItem {
+	return a.item
+}
+
+func (a *testActionable) GetCanvas() fyne.Canvas {
+	return a.mCanvas
+}
+
+func newTestFileAction() *ActionItem {
+	info := NewActionItem("Info", false, false, nil, false, false, false, 0, nil, nil)
+	info.Content = func() fyne.CanvasObject {
+		return widget.NewLabel("info")
+	}
+	options := NewActionItem("Options", false, false, nil, false, false, false, 0, nil, nil)
+	options.Content = func() fyne.CanvasObject {
+		return widget.NewLabel("options")
+	}
+	return NewActionItem("File", false, false, nil, false, false, false, 0, nil, []*ActionItem{info, options})
+}
+
+func TestBackstageOpenClose(t *testing.T) {
+	a := test.NewApp()
+	defer a.Quit()
+	w := a.NewWindow("backstage")
+	defer w.Close()
+	w.SetContent(widget.NewLabel("content"))
+	w.Resize(fyne.NewSize(600., 400.))
+
+	bs := NewBackstage(newTestFileAction(), w.Canvas())
+	closed := 0
+	bs.OnClosed = func() {
+		closed++
+	}
+
+	bs.Open()
+	if !bs.IsOpen() || len(w.Canvas().Overlays().List()) != 1 {
+		t.Fatal("Backstage is not shown after Open")
+	}
+	if bs.selected == nil || bs.selected != bs.navItems[0] {
+		t.Error("first entry is not selected after Open")
+	}
+	if len(bs.listened) == 0 {
+		t.Error("open Backstage does not listen to its ActionItem tree")
+	}
+
+	// Escape typed in the focused navigation list closes the Backstage
+	bs.mNavList.Select(1)
+	focused := w.Canvas().Focused()
+	if focused == nil {
+		t.Fatal("nothing is focused in the open Backstage")
+	}
+	focused.TypedKey(&fyne.KeyEvent{Name: fyne.KeyEscape})
+	if bs.IsOpen() || len(w.Canvas().Overlays().List()) != 0 {
+		t.Fatal("Escape did not close the Backstage")
+	}
+	if closed != 1 {
+		t.Errorf("OnClosed called %d times, want 1", closed)
+	}
+	if len(bs.listened) != 0 {
+		t.Error("closed Backstage still listens to its ActionItem tree")
+	}
+
+	bs.Open()
+	bs.Close()
+	if bs.IsOpen() || closed != 2 {
+		t.Error("Close did not close the Backstage")
+	}
+}
+
+func TestBackstageTabKeepsSelection(t *testing.T) {
+	a := test.NewApp()
+	defer a.Quit()
+	w := a.NewWindow("backstage")
+	defer w.Close()
+
+	home := container.NewTabItem("Home", widget.NewLabel("home"))
+	view := container.NewTabItem("View", widget.NewLabel("view"))
+	tabs := container.NewAppTabs(home, view)
+	tabs.Select(view)
+	w.SetContent(tabs)
+	w.Resize(fyne.NewSize(600., 400.))
+
+	selected := 0
+	tabs.OnSelected = func(*container.TabItem) {
+		selected++
+	}
+	fileTab, bs := BuildTabItemBackstage(&testActionable{item: newTestFileAction(), mCanvas: w.Canvas()}, tabs)
+	selected = 0
+
+	tabs.Select(fileTab)
+	if !bs.IsOpen() {
+		t.Fatal("selecting the File tab did not open the Backstage")
+	}
+	if tabs.Selected() != view {
+		t.Error("previous tab is not selected while the Backstage is open")
+	}
+	if selected != 0 {
+		t.Errorf("application OnSelected called %d times while opening the Backstage, want 0", selected)
+	}
+	bs.Close()
+
+	tabs.Select(home)
+	if selected != 1 {
+		t.Errorf("application OnSelected called %d times selecting a tab, want 1", selected)
+	}
+}
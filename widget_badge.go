@@ -0,0 +1,264 @@
+package fyneextensions
+
+import (
+	"fmt"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"image/color"
+)
+
+// BadgeCorner defines the corner of the host object where a Badge is displayed
+type BadgeCorner int
+
+const (
+	BadgeTopTrailing BadgeCorner = iota
+	BadgeTopLeading
+	BadgeBottomTrailing
+	BadgeBottomLeading
+)
+
+// BadgeSeverity defines the background color of a Badge
+type BadgeSeverity int
+
+const (
+	BadgeInfo BadgeSeverity = iota
+	BadgeSuccess
+	BadgeWarning
+	BadgeError
+)
+
+// Color returns the current theme color used as background for the severity
+func (s BadgeSeverity) Color() color.Color {
+	switch s {
+	case BadgeSuccess:
+		return theme.SuccessColor()
+	case BadgeWarning:
+		return theme.WarningColor()
+	case BadgeError:
+		return theme.ErrorColor()
+	default:
+		return theme.PrimaryColor()
+	}
+}
+
+/*
+TextColor returns the current theme color used for the text on the severity background. As for the widget.Button
+importance styles, it is the theme background color, readable on the accent colors in both theme variants
+*/
+func (s BadgeSeverity) TextColor() color.Color {
+	return theme.BackgroundColor()
+}
+
+/*
+Badge is a Fyne compatible widget showing a short text or a count (e.g. "3" unread items, "!" for errors)
+on a corner of a FlexButton or of a MiniWidget header, see FlexButton.SetBadge and MiniWidget.SetBadge.
+
+Its content is bound to a binding.String (Texter) or to a binding.Int (Counter); when both are defined
+the text takes precedence. The Badge is hidden when the text is empty and the count is zero.
+The background and text colors depend on the severity and follow the current theme.
+Its height is Scale times the image height of the host object.
+
+An instance of Badge can be created with the factory NewBadge
+*/
+type Badge struct {
+	widget.BaseWidget
+
+	mBackground *canvas.Rectangle
+	mText       *canvas.Text
+	mContainer  *fyne.Container
+
+	severity BadgeSeverity
+	mHost    *fyne.Container
+	copies   []*Badge
+	original *Badge
+
+	Corner BadgeCorner
+	Scale  float32
+
+	Texter  binding.String
+	Counter binding.Int
+}
+
+/*
+NewBadge is the factory function for Badge object
+
+it requires the following inputs:
+- texter: a binding.String defining the text of the Badge, can be nil.
+- counter: a binding.Int defining the count shown by the Badge, can be nil. Counts above 99 are shown as "99+".
+- severity: the severity defining the Badge color.
+- corner: the corner of the host object where the Badge is displayed.
+*/
+func NewBadge(texter binding.String, counter binding.Int, severity BadgeSeverity, corner BadgeCorner) *Badge {
+	b := &Badge{
+		mBackground: canvas.NewRectangle(color.Transparent),
+		mText:       canvas.NewText("", severity.TextColor()),
+		severity:    severity,
+		Corner:      corner,
+		Scale:       .5,
+		Texter:      texter,
+		Counter:     counter,
+	}
+	b.ExtendBaseWidget(b)
+
+	b.mText.TextStyle.Bold = true
+	b.mText.Alignment = fyne.TextAlignCenter
+	b.mContainer = container.NewStack(b.mBackground, container.NewCenter(b.mText))
+
+	if texter != nil {
+		texter.AddListener(b)
+	}
+	if counter != nil {
+		counter.AddListener(b)
+	}
+	b.DataChanged()
+
+	return b
+}
+
+func (b *Badge) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(b.mContainer)
+}
+
+// Resize scales the text and the rounded corners to the Badge height
+func (b *Badge) Resize(size fyne.Size) {
+	b.mText.TextSize = size.Height * .6
+	b.mBackground.CornerRadius = size.Height / 2.
+	b.BaseWidget.Resize(size)
+}
+
+// sizeFor returns the size of the Badge for the given height, wide enough to contain the text
+func (b *Badge) sizeFor(height float32) fyne.Size {
+	txtSz := fyne.MeasureText(b.mText.Text, height*.6, b.mText.TextStyle)
+	width := txtSz.Width + height/2.
+	if width < height {
+		width = height
+	}
+	return fyne.NewSize(width, height)
+}
+
+// SetSeverity changes the severity, and so the color, of the Badge and of its copies
+func (b *Badge) SetSeverity(severity BadgeSeverity) {
+	b.severity = severity
+	b.Refresh()
+	for _, o := range b.copies {
+		o.SetSeverity(severity)
+	}
+}
+
+/*
+copy creates a new Badge with the same bindings, corner and scale. Severity changes of b are propagated to the copy.
+It is used to show the Badge of an ActionItem on each object built for it
+*/
+func (b *Badge) copy() *Badge {
+	nb := NewBadge(b.Texter, b.Counter, b.severity, b.Corner)
+	nb.Scale = b.Scale
+	nb.original = b
+	b.copies = append(b.copies, nb)
+	return nb
+}
+
+// Severity returns the current severity of the Badge
+func (b *Badge) Severity() BadgeSeverity {
+	return b.severity
+}
+
+func (b *Badge) Refresh() {
+	b.mBackground.FillColor = b.severity.Color()
+	b.mBackground.Refresh()
+	b.mText.Color = b.severity.TextColor()
+	b.mText.Refresh()
+}
+
+func (b *Badge) DataChanged() {
+	text := ""
+	if b.Texter != nil {
+		text, _ = b.Texter.Get()
+	}
+	if text == "" && b.Counter != nil {
+		if count, err := b.Counter.Get(); err == nil && count != 0 {
+			if count > 99 {
+				text = "99+"
+			} else {
+				text = fmt.Sprint(count)
+			}
+		}
+	}
+
+	b.mText.Text = text
+	if text == "" {
+		b.Hide()
+	} else {
+		b.Show()
+	}
+	b.Refresh()
+
+	// the size depends on the text length
+	if b.mHost != nil {
+		b.mHost.Refresh()
+	}
+}
+
+// newBadgeHost creates the container placing the Badge on top of its host object
+func newBadgeHost(b *Badge, refHeight func() float32) *fyne.Container {
+	b.mHost = container.New(&badgeLayout{refHeight: refHeight}, b)
+	return b.mHost
+}
+
+// unbind removes the Badge from the listeners of its bindings and from the copies of its original, so that it can be discarded
+func (b *Badge) unbind() {
+	if b.original != nil {
+		for i, o := range b.original.copies {
+			if o == b {
+				b.original.copies = append(b.original.copies[:i], b.original.copies[i+1:]...)
+				break
+			}
+		}
+		b.original = nil
+	}
+	if b.Texter != nil {
+		b.Texter.RemoveListener(b)
+	}
+	if b.Counter != nil {
+		b.Counter.RemoveListener(b)
+	}
+}
+
+/*
+badgeLayout places a Badge on a corner of the container, with height relative to a reference height
+given by the host object (e.g. the FlexButton image height)
+*/
+type badgeLayout struct {
+	refHeight func() float32
+}
+
+func (l *badgeLayout) MinSize([]fyne.CanvasObject) fyne.Size {
+	return fyne.NewSize(0., 0.)
+}
+
+func (l *badgeLayout) Layout(objects []fyne.CanvasObject, containerSize fyne.Size) {
+	for _, o := range objects {
+		b, ok := o.(*Badge)
+		if !ok {
+			continue
+		}
+		height := l.refHeight() * b.Scale
+		if height > containerSize.Height {
+			height = containerSize.Height
+		}
+		size := b.sizeFor(height)
+		b.Resize(size)
+
+		pos := fyne.NewPos(0., 0.)
+		if b.Corner == BadgeTopTrailing || b.Corner == BadgeBottomTrailing {
+			pos.X = containerSize.Width - size.Width
+		}
+		if b.Corner == BadgeBottomLeading || b.Corner == BadgeBottomTrailing {
+			pos.Y = containerSize.Height - size.Height
+		}
+		b.Move(pos)
+	}
+}
@@ -0,0 +1,534 @@
+package fyneextensions
+
+import (
+	"encoding/json"
+	"fmt"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"image/color"
+)
+
+// DockArea identifies a region of a DockManager
+type DockArea int
+
+const (
+	DockLeft DockArea = iota
+	DockRight
+	DockBottom
+	DockCenter
+
+	dockNone DockArea = -1
+)
+
+var dockAreas = []DockArea{DockLeft, DockRight, DockBottom, DockCenter}
+
+// String returns the name of the area, used as key in the saved layout
+func (a DockArea) String() string {
+	switch a {
+	case DockLeft:
+		return "left"
+	case DockRight:
+		return "right"
+	case DockBottom:
+		return "bottom"
+	case DockCenter:
+		return "center"
+	default:
+		return ""
+	}
+}
+
+/*
+DockManager is a Fyne compatible widget hosting MiniWidget components in four regions: left, right and bottom dock
+areas around a central document area, with splitters between them. Empty areas are not shown.
+
+Each area hosts its MiniWidgets in a SideBar, stacked vertically or grouped in tabs (see SetAreaTabbed). The center
+area is tabbed by default. MiniWidgets can be dragged by their header to another area: while dragging, the target
+area is highlighted, and empty areas can be reached by moving the pointer close to their edge of the DockManager.
+Dragging inside a stacked area reorders its MiniWidgets as in a SideBar.
+
+The layout (areas, order, states and split offsets) can be saved and restored with SaveLayout and RestoreLayout,
+keyed by MiniWidget.ID, also as JSON or in fyne.Preferences.
+
+An instance of DockManager can be created with the factory NewDockManager
+*/
+type DockManager struct {
+	widget.BaseWidget
+
+	mContent   *fyne.Container
+	mTree      *fyne.Container
+	mDropLayer *fyne.Container
+	mDropArea  *canvas.Rectangle
+
+	areas     map[DockArea]*dockArea
+	structure string
+	mSplits   map[DockArea]*container.Split
+	offsets   map[DockArea]float64
+
+	dragTarget DockArea
+
+	// OnLayoutChanged is called when a MiniWidget is moved to another area
+	OnLayoutChanged func()
+}
+
+// NewDockManager is the factory function for DockManager object
+func NewDockManager() *DockManager {
+	dm := &DockManager{
+		mTree:      container.NewStack(),
+		mDropLayer: container.NewWithoutLayout(),
+		mDropArea:  canvas.NewRectangle(color.Transparent),
+		areas:      map[DockArea]*dockArea{},
+		mSplits:    map[DockArea]*container.Split{},
+		offsets: map[DockArea]float64{
+			DockLeft:   .2,
+			DockRight:  .75,
+			DockBottom: .7,
+		},
+		dragTarget: dockNone,
+	}
+	dm.mDropArea.StrokeWidth = 2.
+	dm.mDropArea.Hide()
+	dm.mDropLayer.Add(dm.mDropArea)
+	dm.mContent = container.NewStack(dm.mTree, dm.mDropLayer)
+	dm.ExtendBaseWidget(dm)
+
+	for _, a := range dockAreas {
+		dm.areas[a] = newDockArea(dm, a)
+	}
+	dm.areas[DockCenter].setTabbed(true)
+	dm.Refresh()
+
+	return dm
+}
+
+func (dm *DockManager) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(dm.mContent)
+}
+
+func (dm *DockManager) MinSize() fyne.Size {
+	return dm.mContent.MinSize()
+}
+
+func (dm *DockManager) DataChanged() {
+	dm.Refresh()
+}
+
+// AddWidget appends widgets to area. MiniWidgets already hosted by the DockManager are moved there
+func (dm *DockManager) AddWidget(area DockArea, widgets ...*MiniWidget) {
+	for _, o := range widgets {
+		dm.MoveToArea(o, area, -1)
+	}
+}
+
+// RemoveWidget removes widgets from the DockManager
+func (dm *DockManager) RemoveWidget(widgets ...*MiniWidget) {
+	for _, o := range widgets {
+		if a := dm.AreaOf(o); a != dockNone {
+			dm.areas[a].remove(o)
+			o.closer.RemoveListener(dm)
+		}
+	}
+	dm.Refresh()
+}
+
+// MoveToArea moves mw to position index of area, or at its end when index is negative
+func (dm *DockManager) MoveToArea(mw *MiniWidget, area DockArea, index int) {
+	target, ok := dm.areas[area]
+	if !ok {
+		return
+	}
+
+	if a := dm.AreaOf(mw); a == area {
+		if index >= 0 {
+			target.sideBar.MoveTo(mw, index)
+		}
+		return
+	} else if a != dockNone {
+		dm.areas[a].remove(mw)
+	} else {
+		mw.closer.AddListener(dm)
+	}
+
+	target.insert(mw, index)
+	dm.Refresh()
+
+	if dm.OnLayoutChanged != nil {
+		dm.OnLayoutChanged()
+	}
+}
+
+// AreaOf returns the area hosting mw, or -1 if mw is not hosted by the DockManager
+func (dm *DockManager) AreaOf(mw *MiniWidget) DockArea {
+	for _, a := range dockAreas {
+		if dm.areas[a].sideBar.IndexOf(mw) >= 0 {
+			return a
+		}
+	}
+	return dockNone
+}
+
+// Widgets returns the MiniWidgets of area in their current order
+func (dm *DockManager) Widgets(area DockArea) []*MiniWidget {
+	if a, ok := dm.areas[area]; ok {
+		return a.sideBar.Widgets()
+	}
+	return nil
+}
+
+// SetAreaTabbed groups the MiniWidgets of area in tabs (true) or stacks them vertically (false)
+func (dm *DockManager) SetAreaTabbed(area DockArea, tabbed bool) {
+	if a, ok := dm.areas[area]; ok {
+		a.setTabbed(tabbed)
+		dm.Refresh()
+	}
+}
+
+// IsAreaTabbed returns true if the MiniWidgets of area are grouped in tabs
+func (dm *DockManager) IsAreaTabbed(area DockArea) bool {
+	if a, ok := dm.areas[area]; ok {
+		return a.isTabbed()
+	}
+	return false
+}
+
+// SideBar returns the SideBar hosting the MiniWidgets of area
+func (dm *DockManager) SideBar(area DockArea) *SideBar {
+	if a, ok := dm.areas[area]; ok {
+		return a.sideBar
+	}
+	return nil
+}
+
+func (dm *DockManager) Refresh() {
+	structure := ""
+	for _, a := range dockAreas {
+		structure += fmt.Sprint(dm.areas[a].isEmpty())
+	}
+	if structure == dm.structure {
+		dm.mTree.Refresh()
+		return
+	}
+	dm.structure = structure
+	dm.rebuild()
+}
+
+// rebuild recreates the splits between the areas which are not empty, keeping their offsets
+func (dm *DockManager) rebuild() {
+	for a, split := range dm.mSplits {
+		dm.offsets[a] = split.Offset
+	}
+	dm.mSplits = map[DockArea]*container.Split{}
+
+	var tree fyne.CanvasObject = dm.areas[DockCenter].sideBar
+	if !dm.areas[DockBottom].isEmpty() {
+		split := container.NewVSplit(tree, dm.areas[DockBottom].sideBar)
+		split.Offset = dm.offsets[DockBottom]
+		dm.mSplits[DockBottom] = split
+		tree = split
+	}
+	if !dm.areas[DockLeft].isEmpty() {
+		split := container.NewHSplit(dm.areas[DockLeft].sideBar, tree)
+		split.Offset = dm.offsets[DockLeft]
+		dm.mSplits[DockLeft] = split
+		tree = split
+	}
+	if !dm.areas[DockRight].isEmpty() {
+		split := container.NewHSplit(tree, dm.areas[DockRight].sideBar)
+		split.Offset = dm.offsets[DockRight]
+		dm.mSplits[DockRight] = split
+		tree = split
+	}
+
+	dm.mTree.Objects = []fyne.CanvasObject{tree}
+	dm.mTree.Refresh()
+}
+
+// dragWidget highlights the area under the pointer, or forwards the drag to the SideBar hosting mw
+func (dm *DockManager) dragWidget(mw *MiniWidget, ev *fyne.DragEvent) {
+	source := dm.areas[dm.AreaOf(mw)]
+	if source == nil {
+		return
+	}
+
+	dm.dragTarget = dm.targetArea(ev.AbsolutePosition)
+	if dm.dragTarget == source.area && !source.isTabbed() {
+		dm.hideDropArea()
+		source.sideBar.dragChild(mw, ev)
+		return
+	}
+	source.sideBar.cancelDrag()
+
+	if dm.dragTarget == dockNone || dm.dragTarget == source.area {
+		dm.hideDropArea()
+		return
+	}
+
+	pos, size := dm.targetBounds(dm.dragTarget)
+	fill := theme.FocusColor()
+	r, g, b, _ := ToNRGBA(fill)
+	dm.mDropArea.FillColor = &color.NRGBA{R: uint8(r), G: uint8(g), B: uint8(b), A: 48}
+	dm.mDropArea.StrokeColor = fill
+	dm.mDropArea.Move(pos)
+	dm.mDropArea.Resize(size)
+	dm.mDropArea.Show()
+	dm.mDropLayer.Refresh()
+}
+
+// dropWidget moves mw to the highlighted area, or completes the reordering inside its SideBar
+func (dm *DockManager) dropWidget(mw *MiniWidget) {
+	dm.hideDropArea()
+	target := dm.dragTarget
+	dm.dragTarget = dockNone
+
+	source := dm.areas[dm.AreaOf(mw)]
+	if source == nil {
+		return
+	}
+	if target == source.area {
+		if !source.isTabbed() {
+			source.sideBar.dropChild(mw)
+		}
+		return
+	}
+	source.sideBar.cancelDrag()
+	if target != dockNone {
+		dm.MoveToArea(mw, target, -1)
+	}
+}
+
+func (dm *DockManager) hideDropArea() {
+	if dm.mDropArea.Visible() {
+		dm.mDropArea.Hide()
+		dm.mDropLayer.Refresh()
+	}
+}
+
+// dockEdge returns the size of the zones, along the DockManager edges, used to drop in empty areas
+func (dm *DockManager) dockEdge() float32 {
+	return theme.IconInlineSize() * 3.
+}
+
+// targetArea returns the area where a MiniWidget would be dropped at the absolute position pos
+func (dm *DockManager) targetArea(pos fyne.Position) DockArea {
+	d := fyne.CurrentApp().Driver()
+	rel := pos.Subtract(d.AbsolutePositionForObject(dm))
+	size := dm.Size()
+	if rel.X < 0 || rel.Y < 0 || rel.X > size.Width || rel.Y > size.Height {
+		return dockNone
+	}
+
+	edge := dm.dockEdge()
+	if dm.areas[DockLeft].isEmpty() && rel.X < edge {
+		return DockLeft
+	}
+	if dm.areas[DockRight].isEmpty() && rel.X > size.Width-edge {
+		return DockRight
+	}
+	if dm.areas[DockBottom].isEmpty() && rel.Y > size.Height-edge {
+		return DockBottom
+	}
+
+	for _, a := range dockAreas {
+		area := dm.areas[a]
+		if a == DockCenter || area.isEmpty() {
+			continue
+		}
+		aPos := d.AbsolutePositionForObject(area.sideBar)
+		aSize := area.sideBar.Size()
+		if pos.X >= aPos.X && pos.X <= aPos.X+aSize.Width && pos.Y >= aPos.Y && pos.Y <= aPos.Y+aSize.Height {
+			return a
+		}
+	}
+	return DockCenter
+}
+
+// targetBounds returns the position, relative to the DockManager, and the size of the highlight for area
+func (dm *DockManager) targetBounds(a DockArea) (fyne.Position, fyne.Size) {
+	size := dm.Size()
+	edge := dm.dockEdge()
+	area := dm.areas[a]
+	if area.isEmpty() && a != DockCenter {
+		switch a {
+		case DockLeft:
+			return fyne.NewPos(0., 0.), fyne.NewSize(edge, size.Height)
+		case DockRight:
+			return fyne.NewPos(size.Width-edge, 0.), fyne.NewSize(edge, size.Height)
+		default:
+			return fyne.NewPos(0., size.Height-edge), fyne.NewSize(size.Width, edge)
+		}
+	}
+	d := fyne.CurrentApp().Driver()
+	pos := d.AbsolutePositionForObject(area.sideBar).Subtract(d.AbsolutePositionForObject(dm))
+	return pos, area.sideBar.Size()
+}
+
+// DockAreaLayout is the saved layout of an area of a DockManager
+type DockAreaLayout struct {
+	Tabbed bool          `json:"tabbed"`
+	Layout SideBarLayout `json:"layout"`
+}
+
+// DockLayout is the layout of a DockManager as saved by DockManager.SaveLayout. It can be stored as JSON
+type DockLayout struct {
+	Areas   map[string]DockAreaLayout `json:"areas"`
+	Offsets map[string]float64        `json:"offsets,omitempty"`
+}
+
+// SaveLayout returns the current layout of the DockManager. MiniWidgets without ID are not saved
+func (dm *DockManager) SaveLayout() DockLayout {
+	l := DockLayout{Areas: map[string]DockAreaLayout{}, Offsets: map[string]float64{}}
+	for _, a := range dockAreas {
+		l.Areas[a.String()] = dm.areas[a].saveLayout()
+	}
+	for a, offset := range dm.offsets {
+		l.Offsets[a.String()] = offset
+	}
+	for a, split := range dm.mSplits {
+		l.Offsets[a.String()] = split.Offset
+	}
+	return l
+}
+
+/*
+RestoreLayout applies a layout saved with SaveLayout. MiniWidgets are moved to their saved area, and then each area
+restores its order and states. MiniWidgets of the layout which are not hosted by the DockManager are ignored, while
+hosted MiniWidgets missing from the layout stay where they are.
+*/
+func (dm *DockManager) RestoreLayout(l DockLayout) {
+	byID := map[string]*MiniWidget{}
+	for _, a := range dockAreas {
+		for _, o := range dm.areas[a].sideBar.Widgets() {
+			if o.ID != "" {
+				byID[o.ID] = o
+			}
+		}
+	}
+
+	for _, a := range dockAreas {
+		al, ok := l.Areas[a.String()]
+		if !ok {
+			continue
+		}
+		dm.areas[a].setTabbed(al.Tabbed)
+		for _, p := range al.Layout.Panels {
+			if o, ok := byID[p.ID]; ok && dm.AreaOf(o) != a {
+				dm.areas[dm.AreaOf(o)].remove(o)
+				dm.areas[a].insert(o, -1)
+			}
+		}
+	}
+	for _, a := range dockAreas {
+		if al, ok := l.Areas[a.String()]; ok {
+			dm.areas[a].restoreLayout(al)
+		}
+	}
+
+	for _, a := range dockAreas {
+		if offset, ok := l.Offsets[a.String()]; ok && a != DockCenter {
+			dm.offsets[a] = offset
+		}
+	}
+	dm.mSplits = map[DockArea]*container.Split{}
+	dm.structure = ""
+	dm.Refresh()
+
+	if dm.OnLayoutChanged != nil {
+		dm.OnLayoutChanged()
+	}
+}
+
+// SaveLayoutJSON returns the current layout of the DockManager encoded as JSON
+func (dm *DockManager) SaveLayoutJSON() ([]byte, error) {
+	return json.Marshal(dm.SaveLayout())
+}
+
+// RestoreLayoutJSON applies a layout encoded as JSON by SaveLayoutJSON
+func (dm *DockManager) RestoreLayoutJSON(data []byte) error {
+	var l DockLayout
+	if err := json.Unmarshal(data, &l); err != nil {
+		return err
+	}
+	dm.RestoreLayout(l)
+	return nil
+}
+
+// SaveLayoutToPreferences stores the current layout of the DockManager as JSON in the preferences, under key
+func (dm *DockManager) SaveLayoutToPreferences(prefs fyne.Preferences, key string) error {
+	data, err := dm.SaveLayoutJSON()
+	if err != nil {
+		return err
+	}
+	prefs.SetString(key, string(data))
+	return nil
+}
+
+// RestoreLayoutFromPreferences applies the layout stored in the preferences under key, if any
+func (dm *DockManager) RestoreLayoutFromPreferences(prefs fyne.Preferences, key string) error {
+	data := prefs.String(key)
+	if data == "" {
+		return nil
+	}
+	return dm.RestoreLayoutJSON([]byte(data))
+}
+
+// dockArea is a region of a DockManager. Its MiniWidgets are children of a SideBar, stacked or tabbed
+type dockArea struct {
+	dm      *DockManager
+	area    DockArea
+	sideBar *SideBar
+}
+
+func newDockArea(dm *DockManager, area DockArea) *dockArea {
+	return &dockArea{
+		dm:      dm,
+		area:    area,
+		sideBar: NewSideBar(),
+	}
+}
+
+func (a *dockArea) isTabbed() bool {
+	return a.sideBar.Mode() == SideBarTabbed
+}
+
+// isEmpty returns true if the area has no MiniWidget, or if they are all closed
+func (a *dockArea) isEmpty() bool {
+	for _, o := range a.sideBar.mObjects {
+		if !o.IsClosed() {
+			return false
+		}
+	}
+	return true
+}
+
+func (a *dockArea) insert(mw *MiniWidget, index int) {
+	if index < 0 {
+		index = len(a.sideBar.mObjects)
+	}
+	a.sideBar.InsertWidget(index, mw)
+	a.sideBar.SelectTab(mw)
+	mw.onDragged = a.dm.dragWidget
+	mw.onDragEnd = a.dm.dropWidget
+}
+
+func (a *dockArea) remove(mw *MiniWidget) {
+	a.sideBar.RemoveWidget(mw)
+}
+
+func (a *dockArea) setTabbed(tabbed bool) {
+	if tabbed {
+		a.sideBar.SetMode(SideBarTabbed)
+	} else {
+		a.sideBar.SetMode(SideBarSplit)
+	}
+}
+
+func (a *dockArea) saveLayout() DockAreaLayout {
+	return DockAreaLayout{Tabbed: a.isTabbed(), Layout: a.sideBar.SaveLayout()}
+}
+
+func (a *dockArea) restoreLayout(l DockAreaLayout) {
+	a.sideBar.RestoreLayout(l.Layout)
+}
@@ -0,0 +1,66 @@
+package fyneextensions
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+)
+
+func newTestMiniWidget(id string, mCanvas fyne.Canvas) *MiniWidget {
+	mw := NewMiniWidget(id, true, 20., widget.NewLabel(id), false, true, nil, true, nil, true, nil, nil, false, nil, nil, nil, nil, nil, mCanvas)
+	mw.ID = id
+	return mw
+}
+
+func TestDockManagerLayoutRoundTrip(t *testing.T) {
+	a := test.NewApp()
+	defer a.Quit()
+	w := a.NewWindow("dock")
+	defer w.Close()
+
+	dm := NewDockManager()
+	w.SetContent(dm)
+	w.Resize(fyne.NewSize(800., 600.))
+
+	left1 := newTestMiniWidget("left1", w.Canvas())
+	left2 := newTestMiniWidget("left2", w.Canvas())
+	bottom := newTestMiniWidget("bottom", w.Canvas())
+	doc1 := newTestMiniWidget("doc1", w.Canvas())
+	doc2 := newTestMiniWidget("doc2", w.Canvas())
+	dm.AddWidget(DockLeft, left1, left2)
+	dm.AddWidget(DockBottom, bottom)
+	dm.AddWidget(DockCenter, doc1, doc2)
+	dm.SideBar(DockCenter).SelectTab(doc1)
+
+	data, err := dm.SaveLayoutJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dm.MoveToArea(left1, DockRight, -1)
+	dm.MoveToArea(bottom, DockLeft, 0)
+	dm.SetAreaTabbed(DockCenter, false)
+	if got := dm.AreaOf(left1); got != DockRight {
+		t.Fatalf("left1 is in area %v after the move, want %v", got, DockRight)
+	}
+
+	if err := dm.RestoreLayoutJSON(data); err != nil {
+		t.Fatal(err)
+	}
+	for mw, area := range map[*MiniWidget]DockArea{left1: DockLeft, left2: DockLeft, bottom: DockBottom, doc1: DockCenter, doc2: DockCenter} {
+		if got := dm.AreaOf(mw); got != area {
+			t.Errorf("%s is in area %v, want %v", mw.ID, got, area)
+		}
+	}
+	if got := dm.Widgets(DockLeft); len(got) != 2 || got[0] != left1 || got[1] != left2 {
+		t.Errorf("left area order is %v, want [left1 left2]", got)
+	}
+	if !dm.IsAreaTabbed(DockCenter) {
+		t.Error("center area is not tabbed after restore")
+	}
+	if got := dm.SideBar(DockCenter).SelectedTab(); got != doc1 {
+		t.Errorf("selected center tab is %v, want doc1", got)
+	}
+}
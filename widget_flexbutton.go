@@ -10,6 +10,7 @@ import (
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 	"image/color"
+	"sync"
 	"time"
 )
 
@@ -27,8 +28,20 @@ The main additional functionalities over the standard widget.Button object are t
 - encapsulates state: the object can hold an int state value, based on binding.Int object, depending on which it will display different image
 - can be hidden or disabled via binding.Bool objects
 - when binding.String tool tip is defined, it will push its text to the binding object when mouse is over.
-If not, the text will be displayed on a tooltip popup
+If not, the text will be displayed on a tooltip by the ToolTipManager of the canvas (see AddToolTipLayer), when it is not
+visible on the button
 - it can include a side image when the button triggers a sub-menu
+- it can work as a split button, where the side image opens a menu and the rest of the button calls OnTapped
+- it is focusable: Space or Enter call OnTapped, F4 opens the split menu, arrow keys move the focus to the previous or
+next button of the same group (e.g. a ribbon group or a MiniWidget header). The focused button shows a border
+colored with theme.FocusColor
+- it can show a Badge on one of its corners, see SetBadge
+- besides OnTapped, it provides OnSecondaryTapped (right click), OnDoubleTapped and OnLongPressed callbacks.
+Double tap does not delay OnTapped: the first tap calls OnTapped, the second one OnDoubleTapped
+- Importance defines the button style as for widget.Button (medium, high, danger, warning, success, low): background,
+hover, pressed and text colors follow the theme colors of the importance, see SetImportance
+- disabled buttons show a greyed version of the state image, or the explicit disabled images defined with
+SetDisabledResources. SVG images can follow the theme foreground color, see SetThemedIcons
 
 It is the basic object for the MainRibbon widget
 */
@@ -45,22 +58,52 @@ type FlexButton struct {
 	mTextSize          float32
 	mTextString        string
 
-	mTextLabel  *SizableLabel
-	mPrimImage  []*canvas.Image
-	mSideImage  *canvas.Image
+	mTextLabel *SizableLabel
+	mPrimImage []*canvas.Image
+	mSideImage *canvas.Image
+
+	mResources         []fyne.Resource
+	mDisabledResources []fyne.Resource
+	mSideResource      fyne.Resource
+	themedIcons        bool
+
+	mSideBG     *canvas.Rectangle
+	mSideCnt    *fyne.Container
 	mBackground *canvas.Rectangle
 	mContainer  *fyne.Container
 
-	OnTapped func(int)
+	OnTapped   func(int)
+	Importance widget.Importance
+
+	// OnSecondaryTapped is called on right click, and on long press on mobile devices when OnLongPressed is nil
+	OnSecondaryTapped func(int, *fyne.PointEvent)
+	// OnDoubleTapped is called in place of OnTapped on the second of two taps in quick succession
+	OnDoubleTapped func(int, *fyne.PointEvent)
+	// OnLongPressed is called when the primary button is kept pressed, or on long press on mobile devices
+	OnLongPressed func(int, *fyne.PointEvent)
+
+	lastTap        time.Time
+	longPressTimer *time.Timer
+	isLongPressed  bool
+	longPressLock  sync.Mutex // also guards mState, read by the long press timer
+
+	isSplit    bool
+	splitMenu  *fyne.Menu
+	hoverSide  bool
+	isHovering bool
+
+	hasFocus     bool
+	mFocusBorder *canvas.Rectangle
+	mFocusRoot   fyne.CanvasObject
+
+	mBadge    *Badge
+	mBadgeCnt *fyne.Container
 
 	tapAnim *fyne.Animation
 	tapBG   *canvas.Rectangle
 
-	mCanvas     fyne.Canvas
-	mPopUp      *widget.PopUp
-	mPupLbl     *SizableLabel
-	mPopUpTimer *time.Ticker
-	mRelPos     fyne.Position
+	mCanvas   fyne.Canvas
+	mToolTips *ToolTipManager
 
 	Texter     binding.String
 	Disabler   binding.Bool
@@ -96,9 +139,11 @@ func NewFlexButton(text string, images []fyne.Resource, isTextAndIconLaidHorizon
 	t := &FlexButton{
 		mBackground:        canvas.NewRectangle(theme.ButtonColor()),
 		tapBG:              canvas.NewRectangle(color.Transparent),
+		mFocusBorder:       canvas.NewRectangle(color.Transparent),
 		OnTapped:           onTapped,
 		mSize:              fyne.NewSize(0., fullHeight),
 		mCanvas:            mCanvas,
+		mResources:         images,
 		textIconHorizontal: isTextAndIconLaidHorizontal,
 		compressText:       compressText,
 		hasMoreIcon:        hasMoreIcon,
@@ -114,7 +159,7 @@ func NewFlexButton(text string, images []fyne.Resource, isTextAndIconLaidHorizon
 	}
 	t.ExtendBaseWidget(t)
 
-	t.tapAnim = newButtonTapAnimation(t.tapBG, t)
+	t.tapAnim = newButtonTapAnimation(t.tapBG, t, t.pressedColor)
 	t.tapAnim.Curve = fyne.AnimationEaseOut
 
 	imMinSize := fyne.NewSize(0., 0.)
@@ -214,10 +259,11 @@ func NewFlexButton(text string, images []fyne.Resource, isTextAndIconLaidHorizon
 	var mItm2 *fyne.Container
 	if hasMoreIcon {
 		if isTextAndIconLaidHorizontal {
-			t.mSideImage = canvas.NewImageFromResource(theme.MenuExpandIcon())
+			t.mSideResource = theme.MenuExpandIcon()
 		} else {
-			t.mSideImage = canvas.NewImageFromResource(theme.MenuDropDownIcon())
+			t.mSideResource = theme.MenuDropDownIcon()
 		}
+		t.mSideImage = canvas.NewImageFromResource(t.mSideResource)
 		t.mSideImage.FillMode = canvas.ImageFillOriginal
 		t.mSideImage.ScaleMode = canvas.ImageScaleSmooth
 		t.mSideImage.SetMinSize(fyne.NewSize(t.mTextSize*t.mSideImage.Aspect(), t.mTextSize))
@@ -225,32 +271,27 @@ func NewFlexButton(text string, images []fyne.Resource, isTextAndIconLaidHorizon
 			if t.mTextSize*t.mSideImage.Aspect() > t.mSize.Width {
 				t.mSize.Width = t.mTextSize * t.mSideImage.Aspect()
 			}
-			mItm2 = container.New(&ExpandingFirstUnpaddedVBox{}, mItm, container.New(&StackFixedRatioUnpadded{}, t.mSideImage))
 		} else {
 			t.mSize.Width += t.mTextSize * t.mSideImage.Aspect()
-			mItm2 = container.New(&ExpandingFirstUnpaddedHBox{}, mItm, container.New(&StackFixedRatioUnpadded{}, t.mSideImage))
+		}
+		t.mSideBG = canvas.NewRectangle(color.Transparent)
+		t.mSideCnt = container.NewStack(t.mSideBG, container.New(&StackFixedRatioUnpadded{}, t.mSideImage))
+		if isMoreIconBelow {
+			mItm2 = container.New(&ExpandingFirstUnpaddedVBox{}, mItm, t.mSideCnt)
+		} else {
+			mItm2 = container.New(&ExpandingFirstUnpaddedHBox{}, mItm, t.mSideCnt)
 		}
 	} else {
 		mItm2 = mItm
 	}
-	t.mContainer = container.NewStack(t.mBackground, t.tapBG, mItm2)
+	t.mFocusBorder.StrokeColor = theme.FocusColor()
+	t.mFocusBorder.StrokeWidth = 2.
+	t.mFocusBorder.Hide()
+	t.mContainer = container.NewStack(t.mBackground, t.tapBG, mItm2, t.mFocusBorder)
 
 	if t.ToolTipper == nil && mCanvas != nil {
 		if text != "" || texter != nil {
-			t.mPupLbl = NewSizableLabel(text, 20., false, false, theme.ForegroundColor(), color.Transparent)
-			t.mPopUp = widget.NewPopUp(t.mPupLbl, mCanvas)
-			t.mPopUp.Hide()
-			t.mPopUpTimer = time.NewTicker(2 * time.Second)
-			t.mPopUpTimer.Stop()
-			go func() {
-				for {
-					<-t.mPopUpTimer.C
-					nPos := fyne.NewPos(t.mRelPos.X, t.mRelPos.Y)
-					nPos.Y -= t.mPopUp.MinSize().Height
-					t.mPopUp.ShowAtRelativePosition(nPos, t)
-					t.mPopUpTimer.Stop()
-				}
-			}()
+			t.mToolTips = ToolTipManagerForCanvas(mCanvas)
 		}
 	}
 
@@ -280,16 +321,9 @@ func (t *FlexButton) MinSize() fyne.Size {
 }
 
 func (t *FlexButton) Refresh() {
-	if t.mSideImage != nil {
-		t.mSideImage.Refresh()
-	}
-
-	if !t.Disabled() {
-		t.mBackground.FillColor = theme.ButtonColor()
-	} else {
-		t.mBackground.FillColor = theme.DisabledColor()
-	}
-	t.mBackground.Refresh()
+	t.updateBackground()
+	t.mFocusBorder.StrokeColor = theme.FocusColor()
+	t.mFocusBorder.Refresh()
 
 	if t.Stater != nil {
 		for i, o := range t.mPrimImage {
@@ -301,24 +335,110 @@ func (t *FlexButton) Refresh() {
 		}
 	}
 
+	t.updateImages()
+
 	if t.mTextLabel != nil {
 		//t.mTextLabel.mBackgroundColor = theme.ButtonColor()
-		t.mTextLabel.mTextColor = theme.ForegroundColor()
+		t.mTextLabel.mTextColor = t.textColor()
 		t.mTextLabel.Refresh()
 	}
-	if t.mPopUp != nil {
-		t.mPupLbl.mTextColor = theme.ForegroundColor()
-		t.mPopUp.Refresh()
+
+}
+
+const (
+	flexButtonDoubleTapDelay = 300 * time.Millisecond
+	flexButtonLongPressDelay = 600 * time.Millisecond
+)
+
+func (t *FlexButton) Tapped(ev *fyne.PointEvent) {
+	t.stopLongPress()
+	t.longPressLock.Lock()
+	isLongPressed := t.isLongPressed
+	t.isLongPressed = false
+	t.longPressLock.Unlock()
+	if isLongPressed {
+		return
+	}
+
+	onSide := t.isSplit && t.isOnSide(ev.Position)
+	if !onSide && !t.Disabled() && t.OnDoubleTapped != nil && time.Since(t.lastTap) < flexButtonDoubleTapDelay {
+		t.lastTap = time.Time{}
+		t.OnDoubleTapped(t.mState, ev)
+		return
+	}
+	t.lastTap = time.Now()
+
+	t.activate(onSide)
+}
+
+func (t *FlexButton) TappedSecondary(ev *fyne.PointEvent) {
+	if t.Disabled() {
+		return
+	}
+	if fyne.CurrentDevice().IsMobile() && t.OnLongPressed != nil {
+		t.OnLongPressed(t.mState, ev)
+	} else if t.OnSecondaryTapped != nil {
+		t.OnSecondaryTapped(t.mState, ev)
 	}
+}
+
+func (t *FlexButton) MouseDown(me *desktop.MouseEvent) {
+	t.stopLongPress()
+	t.longPressLock.Lock()
+	defer t.longPressLock.Unlock()
+
+	t.isLongPressed = false
+	if me.Button != desktop.MouseButtonPrimary || t.OnLongPressed == nil || t.Disabled() {
+		return
+	}
+
+	ev := me.PointEvent
+	var timer *time.Timer
+	timer = time.AfterFunc(flexButtonLongPressDelay, func() {
+		// the long press is handled on the event goroutine, as the other pointer events
+		runOnEventQueue(t.mCanvas, func() {
+			t.longPressLock.Lock()
+			// the timer may fire while being stopped by MouseUp or MouseOut
+			if t.longPressTimer != timer {
+				t.longPressLock.Unlock()
+				return
+			}
+			t.longPressTimer = nil
+			t.isLongPressed = true
+			onLongPressed := t.OnLongPressed
+			state := t.mState
+			t.longPressLock.Unlock()
+
+			onLongPressed(state, &ev)
+		})
+	})
+	t.longPressTimer = timer
+}
 
+func (t *FlexButton) MouseUp(*desktop.MouseEvent) {
+	t.stopLongPress()
 }
 
-func (t *FlexButton) Tapped(*fyne.PointEvent) {
+// stopLongPress cancels the pending long press, if any
+func (t *FlexButton) stopLongPress() {
+	t.longPressLock.Lock()
+	defer t.longPressLock.Unlock()
+
+	if t.longPressTimer != nil {
+		t.longPressTimer.Stop()
+		t.longPressTimer = nil
+	}
+}
+
+// activate calls OnTapped, or opens the split menu when onSide is true, and plays the tap animation
+func (t *FlexButton) activate(onSide bool) {
 	if !t.Disabled() {
-		if t.OnTapped != nil {
+		if onSide {
+			t.showSplitMenu()
+		} else if t.OnTapped != nil {
 			t.OnTapped(t.mState)
-			if t.mPopUpTimer != nil {
-				t.mPopUpTimer.Stop()
+			if t.mToolTips != nil {
+				t.mToolTips.Hide()
 			}
 		}
 		t.tapAnim.Stop()
@@ -329,13 +449,101 @@ func (t *FlexButton) Tapped(*fyne.PointEvent) {
 	}
 }
 
-func (t *FlexButton) MouseIn(*desktop.MouseEvent) {
-	if t.Disabled() {
-		t.mBackground.FillColor = theme.DisabledColor()
-	} else {
-		t.mBackground.FillColor = blendColor(theme.ButtonColor(), theme.HoverColor())
+func (t *FlexButton) FocusGained() {
+	t.hasFocus = true
+	t.mFocusBorder.Show()
+	t.mFocusBorder.Refresh()
+
+	if t.ToolTipper != nil {
+		t.ToolTipper.Set(t.mTextString)
+	}
+}
+
+func (t *FlexButton) FocusLost() {
+	t.hasFocus = false
+	t.mFocusBorder.Hide()
+	t.mFocusBorder.Refresh()
+
+	if t.ToolTipper != nil && !t.isHovering {
+		t.ToolTipper.Set("")
+	}
+}
+
+func (t *FlexButton) TypedRune(rune) {}
+
+func (t *FlexButton) TypedKey(ev *fyne.KeyEvent) {
+	switch ev.Name {
+	case fyne.KeySpace, fyne.KeyReturn, fyne.KeyEnter:
+		t.activate(false)
+	case fyne.KeyF4:
+		if t.isSplit {
+			t.activate(true)
+		}
+	case fyne.KeyLeft, fyne.KeyUp:
+		t.focusSibling(-1)
+	case fyne.KeyRight, fyne.KeyDown:
+		t.focusSibling(1)
+	}
+}
+
+// focusSibling moves the focus to the enabled FlexButton dir positions away in the focus group, wrapping around
+func (t *FlexButton) focusSibling(dir int) {
+	if t.mFocusRoot == nil || t.mCanvas == nil {
+		return
+	}
+	var buttons []*FlexButton
+	for _, o := range collectFlexButtons(t.mFocusRoot, true) {
+		if o == t || !o.Disabled() {
+			buttons = append(buttons, o)
+		}
+	}
+	index := -1
+	for i, o := range buttons {
+		if o == t {
+			index = i
+			break
+		}
+	}
+	if index < 0 || len(buttons) < 2 {
+		return
 	}
-	t.mBackground.Refresh()
+
+	next := buttons[(index+dir+len(buttons))%len(buttons)]
+	t.mCanvas.Focus(next)
+}
+
+// collectFlexButtons returns the FlexButton found in the container tree of obj, in tree order
+func collectFlexButtons(obj fyne.CanvasObject, visibleOnly bool) (buttons []*FlexButton) {
+	if visibleOnly && !obj.Visible() {
+		return nil
+	}
+	switch o := obj.(type) {
+	case *FlexButton:
+		buttons = append(buttons, o)
+	case *fyne.Container:
+		for _, oo := range o.Objects {
+			buttons = append(buttons, collectFlexButtons(oo, visibleOnly)...)
+		}
+	case *container.Scroll:
+		buttons = collectFlexButtons(o.Content, visibleOnly)
+	}
+	return
+}
+
+/*
+setFocusGroup defines root as the focus group of all the FlexButton contained in it, so that arrow keys move the
+focus among them. Only containers are traversed, FlexButton nested in other widgets are not affected
+*/
+func setFocusGroup(root fyne.CanvasObject) {
+	for _, o := range collectFlexButtons(root, false) {
+		o.mFocusRoot = root
+	}
+}
+
+func (t *FlexButton) MouseIn(me *desktop.MouseEvent) {
+	t.isHovering = true
+	t.hoverSide = t.isSplit && t.isOnSide(me.Position)
+	t.updateBackground()
 
 	if t.mTextLabel != nil {
 		t.mTextLabel.mBackgroundColor = t.mBackground.FillColor
@@ -345,33 +553,45 @@ func (t *FlexButton) MouseIn(*desktop.MouseEvent) {
 	if t.ToolTipper != nil {
 		t.ToolTipper.Set(t.mTextString)
 	}
+	if t.mToolTips != nil {
+		t.mToolTips.MouseInText(t, me.AbsolutePosition, t.toolTipText)
+	}
+}
+
+// toolTipText returns the text shown by the ToolTipManager: it is needed only when the text is compressed
+func (t *FlexButton) toolTipText() string {
+	if t.mTextLabel.Visible() {
+		return ""
+	}
+	return t.mTextString
 }
 
 func (t *FlexButton) MouseMoved(me *desktop.MouseEvent) {
-	if t.mPopUp != nil {
-		t.mRelPos = me.Position
-		if !t.mTextLabel.Visible() && !t.mPopUp.Visible() {
-			t.mPopUpTimer.Reset(2 * time.Second)
+	if t.isSplit {
+		if onSide := t.isOnSide(me.Position); onSide != t.hoverSide {
+			t.hoverSide = onSide
+			t.updateBackground()
 		}
 	}
+
+	if t.mToolTips != nil {
+		t.mToolTips.MouseMoved(t, me.AbsolutePosition)
+	}
 }
 
 func (t *FlexButton) MouseOut() {
-	if t.Disabled() {
-		t.mBackground.FillColor = theme.DisabledColor()
-	} else {
-		t.mBackground.FillColor = theme.ButtonColor()
-	}
-	t.mBackground.Refresh()
+	t.stopLongPress()
+	t.isHovering = false
+	t.hoverSide = false
+	t.updateBackground()
 
 	if t.mTextLabel != nil {
 		t.mTextLabel.mBackgroundColor = t.mBackground.FillColor
 		t.mTextLabel.Refresh()
 	}
 
-	if t.mPopUp != nil {
-		t.mPopUpTimer.Stop()
-		t.mPopUp.Hide()
+	if t.mToolTips != nil {
+		t.mToolTips.MouseOut(t)
 	}
 
 	if t.ToolTipper != nil {
@@ -379,20 +599,221 @@ func (t *FlexButton) MouseOut() {
 	}
 }
 
+// updateBackground sets the background colors depending on importance, disabled and hover state.
+// In split mode the main area and the "more" icon area are highlighted separately
+func (t *FlexButton) updateBackground() {
+	baseColor := t.importanceColor()
+	hoverColor := blendColor(baseColor, theme.HoverColor())
+	disabledColor := theme.DisabledColor()
+	if t.Importance == widget.LowImportance {
+		disabledColor = color.Transparent
+	}
+
+	if t.Disabled() {
+		t.mBackground.FillColor = disabledColor
+	} else if t.isHovering && !t.hoverSide {
+		t.mBackground.FillColor = hoverColor
+	} else {
+		t.mBackground.FillColor = baseColor
+	}
+	t.mBackground.Refresh()
+
+	if t.mSideBG != nil {
+		if !t.isSplit {
+			t.mSideBG.FillColor = color.Transparent
+		} else if t.Disabled() {
+			t.mSideBG.FillColor = disabledColor
+		} else if t.isHovering && t.hoverSide {
+			t.mSideBG.FillColor = hoverColor
+		} else {
+			t.mSideBG.FillColor = baseColor
+		}
+		t.mSideBG.Refresh()
+	}
+}
+
+// SetImportance changes the style of the FlexButton, as widget.Button Importance
+func (t *FlexButton) SetImportance(importance widget.Importance) {
+	t.Importance = importance
+	t.Refresh()
+}
+
+// isStrongImportance returns true for the importance styles painting the background with an accent color
+func (t *FlexButton) isStrongImportance() bool {
+	switch t.Importance {
+	case widget.HighImportance, widget.DangerImportance, widget.WarningImportance, widget.SuccessImportance:
+		return true
+	}
+	return false
+}
+
+// importanceColor returns the background color of the FlexButton when not hovered nor disabled
+func (t *FlexButton) importanceColor() color.Color {
+	switch t.Importance {
+	case widget.HighImportance:
+		return theme.PrimaryColor()
+	case widget.DangerImportance:
+		return theme.ErrorColor()
+	case widget.WarningImportance:
+		return theme.WarningColor()
+	case widget.SuccessImportance:
+		return theme.SuccessColor()
+	case widget.LowImportance:
+		return color.Transparent
+	default:
+		return theme.ButtonColor()
+	}
+}
+
+// pressedColor returns the color of the tap animation, the pressed color blended on accent colors
+func (t *FlexButton) pressedColor() color.Color {
+	if !t.isStrongImportance() {
+		return theme.PressedColor()
+	}
+	r, g, b, _ := ToNRGBA(blendColor(t.importanceColor(), theme.PressedColor()))
+	_, _, _, a := ToNRGBA(theme.PressedColor())
+	return &color.NRGBA{R: uint8(r), G: uint8(g), B: uint8(b), A: uint8(a)}
+}
+
+// textColor returns the text color for the current importance and disabled state
+func (t *FlexButton) textColor() color.Color {
+	if !t.Disabled() && t.isStrongImportance() {
+		return theme.BackgroundColor()
+	}
+	return theme.ForegroundColor()
+}
+
+// updateImages sets the displayed image resources depending on theme, importance and disabled state
+func (t *FlexButton) updateImages() {
+	for i, o := range t.mPrimImage {
+		var disabledRes fyne.Resource
+		if i < len(t.mDisabledResources) {
+			disabledRes = t.mDisabledResources[i]
+		}
+		o.Resource = t.displayedResource(t.mResources[i], disabledRes)
+		o.Refresh()
+	}
+	if t.mSideImage != nil {
+		t.mSideImage.Resource = t.displayedResource(t.mSideResource, nil)
+		t.mSideImage.Refresh()
+	}
+}
+
+/*
+displayedResource returns the resource to display for res: when disabled the explicit disabled resource or
+a greyed version of res, when enabled themed icons are inverted on accent colored backgrounds to stay readable
+*/
+func (t *FlexButton) displayedResource(res fyne.Resource, disabledRes fyne.Resource) fyne.Resource {
+	if t.themedIcons {
+		res = themedResource(res)
+	}
+	if t.Disabled() {
+		if disabledRes != nil {
+			return disabledRes
+		}
+		return greyedResource(res)
+	}
+	if tr, ok := res.(*theme.ThemedResource); ok && t.isStrongImportance() {
+		return invertedResource(tr)
+	}
+	return res
+}
+
+/*
+SetThemedIcons defines if SVG images are colored with the theme foreground color, following theme variant changes.
+It is meant for monochrome icons, colored icons lose their colors
+*/
+func (t *FlexButton) SetThemedIcons(themed bool) {
+	t.themedIcons = themed
+	t.Refresh()
+}
+
+/*
+SetDisabledResources defines the images displayed when the FlexButton is disabled, one per state as the images
+given to NewFlexButton. States without a disabled image show a greyed version of the enabled one
+*/
+func (t *FlexButton) SetDisabledResources(resources ...fyne.Resource) {
+	t.mDisabledResources = resources
+	t.Refresh()
+}
+
+/*
+SetSplitMenu turns the FlexButton in a split button: tapping the main area calls OnTapped, while tapping
+the "more" icon area opens the menu. The two areas have separate hover feedback.
+It requires the FlexButton to be created with hasMoreIcon. Setting a nil menu disables the split mode.
+*/
+func (t *FlexButton) SetSplitMenu(menu *fyne.Menu) {
+	if t.mSideCnt == nil {
+		return
+	}
+	t.splitMenu = menu
+	t.isSplit = menu != nil
+	t.updateBackground()
+}
+
+func (t *FlexButton) isOnSide(pos fyne.Position) bool {
+	if t.mSideCnt == nil || !t.mSideCnt.Visible() {
+		return false
+	}
+	sPos := t.mSideCnt.Position()
+	if t.isMoreIconBelow {
+		return pos.Y >= sPos.Y
+	}
+	return pos.X >= sPos.X
+}
+
+func (t *FlexButton) showSplitMenu() {
+	if t.splitMenu == nil || t.mCanvas == nil {
+		return
+	}
+	if t.isMoreIconBelow {
+		widget.ShowPopUpMenuAtRelativePosition(t.splitMenu, t.mCanvas, fyne.NewPos(0., t.Size().Height), t)
+	} else {
+		widget.ShowPopUpMenuAtRelativePosition(t.splitMenu, t.mCanvas, fyne.NewPos(t.Size().Width, 0.), t)
+	}
+}
+
+/*
+SetBadge shows the Badge on top of the FlexButton, at the Badge corner. The Badge height is relative to the
+image height, or to the text height for buttons without images. A nil Badge removes the current one.
+*/
+func (t *FlexButton) SetBadge(b *Badge) {
+	if t.mBadgeCnt != nil {
+		t.mContainer.Remove(t.mBadgeCnt)
+		t.mBadge.mHost = nil
+		if t.mBadge.original != nil {
+			// copies are created for this button only
+			t.mBadge.unbind()
+		}
+		t.mBadge, t.mBadgeCnt = nil, nil
+	}
+	if b == nil {
+		return
+	}
+	t.mBadge = b
+	t.mBadgeCnt = newBadgeHost(b, func() float32 {
+		if len(t.mPrimImage) > 0 && t.mPrimImage[0].MinSize().Height > 0 {
+			return t.mPrimImage[0].MinSize().Height
+		}
+		return t.mTextSize
+	})
+	t.mContainer.Add(t.mBadgeCnt)
+}
+
 func (t *FlexButton) SetMinSize(size fyne.Size) {
 	for _, o := range t.mPrimImage {
 		o.SetMinSize(size)
 	}
 }
 
-func newButtonTapAnimation(bg *canvas.Rectangle, w fyne.Widget) *fyne.Animation {
+func newButtonTapAnimation(bg *canvas.Rectangle, w fyne.Widget, pressedColor func() color.Color) *fyne.Animation {
 	return fyne.NewAnimation(canvas.DurationStandard, func(done float32) {
 		mid := w.Size().Width / 2
 		size := mid * done
 		bg.Resize(fyne.NewSize(size*2, w.Size().Height))
 		bg.Move(fyne.NewPos(mid-size, 0))
 
-		r, g, bb, a := ToNRGBA(theme.PressedColor())
+		r, g, bb, a := ToNRGBA(pressedColor())
 		aa := uint8(a)
 		fade := aa - uint8(float32(aa)*done)
 		if fade > 0 {
@@ -404,16 +825,34 @@ func newButtonTapAnimation(bg *canvas.Rectangle, w fyne.Widget) *fyne.Animation
 	})
 }
 
+// unbind removes the FlexButton from the listeners of its bindings, so that it can be discarded
+func (t *FlexButton) unbind() {
+	if t.Texter != nil {
+		t.Texter.RemoveListener(t)
+	}
+	if t.Disabler != nil {
+		t.Disabler.RemoveListener(t)
+	}
+	if t.Hider != nil {
+		t.Hider.RemoveListener(t)
+	}
+	if t.Stater != nil {
+		t.Stater.RemoveListener(t)
+	}
+	if t.mToolTips != nil {
+		t.mToolTips.MouseOut(t)
+	}
+	if t.mBadge != nil {
+		t.mBadge.unbind()
+	}
+	t.stopLongPress()
+}
+
 func (t *FlexButton) DataChanged() {
 	if t.Texter != nil {
 		if tx, err := t.Texter.Get(); err == nil {
 			t.mTextString = tx
 
-			if t.mPupLbl != nil {
-				t.mPupLbl.mText.Text = tx
-				t.mPupLbl.Refresh()
-			}
-
 			if t.mTextLabel != nil {
 				if t.mTextLabel.mText.Text != tx {
 					t.mTextLabel.mText.Text = tx
@@ -475,7 +914,9 @@ func (t *FlexButton) DataChanged() {
 	if t.Stater != nil {
 		mi, err := t.Stater.Get()
 		if err == nil {
+			t.longPressLock.Lock()
 			t.mState = mi
+			t.longPressLock.Unlock()
 			t.Refresh()
 		}
 	}
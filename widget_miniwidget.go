@@ -5,14 +5,32 @@ import (
 	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/driver/desktop"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
+	"image/color"
+	"sync"
+)
+
+// MiniWidgetEvent is a change of state of a MiniWidget, see MiniWidget.AddOnStateChanged
+type MiniWidgetEvent int
+
+const (
+	MiniWidgetMinimized MiniWidgetEvent = iota
+	MiniWidgetRestored
+	MiniWidgetClosed
+	MiniWidgetReopened
+	MiniWidgetDetached
+	MiniWidgetDocked
 )
 
 // MiniWidget is a Fyne compatible widget. It represents a miniaturized widget structure in the GUI
 // which can be adapted to multiple usages, such as menu ribbons, sidebars, etc.
 // It's ideal for use-cases like notifications, small data charts, or compact control elements.
-// It can be configured with a header or footer area, with close, minimize, move up and move down buttons
+// It can be configured with a header or footer area, with close, minimize, move up and move down buttons.
+// When detachable (see SetDetachable), its content can be moved to a separate window and docked back, see Detach and Dock.
+// When resizable (see SetResizable), grips on the right and bottom edges and on the bottom-right corner let the user resize it,
+// which is meaningful in free-form containers (e.g. container.NewWithoutLayout) where sizes are not defined by a layout
 // An instance of a MiniWidget can be created with the factory NewMiniWidget
 type MiniWidget struct {
 	widget.DisableableWidget
@@ -27,6 +45,7 @@ type MiniWidget struct {
 	mShadow         *canvas.Rectangle
 	mSeparator      *widget.Separator
 	mSize           float32
+	mCanvas         fyne.Canvas
 
 	disabler  binding.Bool
 	texter    binding.String
@@ -47,6 +66,54 @@ type MiniWidget struct {
 
 	onMore      func(object fyne.CanvasObject)
 	mMoreButton *FlexButton
+
+	mBadge    *Badge
+	mLabelCnt *fyne.Container
+	mTitleCnt *fyne.Container
+
+	headerItems    []*ActionItem
+	headerButtons  []*FlexButton
+	overflowButton *FlexButton
+	overflowMenu   *ActionableMenu
+	headerMenus    []*ActionableMenu
+	showMoreButton bool
+
+	onDragged func(*MiniWidget, *fyne.DragEvent)
+	onDragEnd func(*MiniWidget)
+
+	detachBtn    *FlexButton
+	detachState  binding.Int
+	mWindow      fyne.Window
+	hiddenWindow fyne.Window
+	mPlaceholder fyne.CanvasObject
+	mSlot        fyne.CanvasObject
+
+	mCollapser   *fyne.Container
+	mClip        *container.Scroll
+	collapse     *collapseLayout
+	collapseAnim *fyne.Animation
+	isReady      bool
+
+	mGrips *fyne.Container
+
+	// ResizeMin and ResizeMax constrain the size set with the resize grips. ResizeMax is ignored when zero
+	ResizeMin, ResizeMax fyne.Size
+	// OnResized is called with the new size at the end of a resize with the grips
+	OnResized func(fyne.Size)
+
+	onStateChanged []func(*MiniWidget, MiniWidgetEvent)
+	stateLock      sync.Mutex
+	isMinimized    bool
+	isClosed       bool
+
+	// OnDetachChanged is called when the content is moved to a separate window (true) or docked back (false)
+	OnDetachChanged func(detached bool)
+
+	mIcon         fyne.Resource
+	onIconChanged func()
+
+	// ID identifies the MiniWidget across application runs, e.g. when saving the SideBar layout. It should not change
+	ID string
 }
 
 // NewMiniWidget is a factory function that creates and initializes a new MiniWidget.
@@ -80,12 +147,14 @@ func NewMiniWidget(header string, headerOnTop bool, headerSize float32,
 	texter binding.String, disabler binding.Bool, closer binding.Bool, minimizer binding.Int, mCanvas fyne.Canvas) *MiniWidget {
 	t := &MiniWidget{
 		mSize:       headerSize,
+		mCanvas:     mCanvas,
 		mContent:    content,
 		mHeader:     container.New(&ExpandingFirstUnpaddedHBox{}),
 		mBackground: canvas.NewRectangle(theme.BackgroundColor()),
 		mShadow:     nil,
 		mSeparator:  widget.NewSeparator(),
 		mLabel:      NewSizableLabel(header, headerSize, false, false, theme.ForegroundColor(), theme.ButtonColor()),
+		detachState: binding.NewInt(),
 
 		texter:    texter,
 		disabler:  disabler,
@@ -122,7 +191,13 @@ func NewMiniWidget(header string, headerOnTop bool, headerSize float32,
 	t.mBackground.FillColor = theme.BackgroundColor()
 	t.mBackground.StrokeWidth = 2.
 
-	t.mHeader.Add(t.mLabel)
+	t.mLabelCnt = container.NewStack(t.mLabel)
+	titleLayout := &headerTitleLayout{mw: t}
+	t.overflowButton = NewFlexButton("", []fyne.Resource{theme.MoreHorizontalIcon()}, false, true, false, false, false, headerSize, 0., mCanvas, func(int) {
+		t.showOverflowMenu(titleLayout.overflow)
+	}, nil, nil, nil, nil, nil)
+	t.mTitleCnt = container.New(titleLayout, newMiniWidgetDragArea(t, t.mLabelCnt), t.overflowButton)
+	t.mHeader.Add(t.mTitleCnt)
 
 	t.moveUpBtn = NewFlexButton("", []fyne.Resource{theme.MoveUpIcon()}, false, true, false, false, false, headerSize, 0., mCanvas, func(int) {
 		if t.onMoveUp != nil {
@@ -143,6 +218,7 @@ func NewMiniWidget(header string, headerOnTop bool, headerSize float32,
 
 	t.mMoreButton = NewFlexButton("", []fyne.Resource{theme.MoreVerticalIcon()}, false, true, false, false, false, headerSize, 0., mCanvas, nil, nil, nil, nil, nil, nil)
 	t.mHeader.Add(t.mMoreButton)
+	t.showMoreButton = showMore
 	if !showMore {
 		t.mMoreButton.Hide()
 	}
@@ -152,25 +228,23 @@ func NewMiniWidget(header string, headerOnTop bool, headerSize float32,
 		}
 	}
 
+	t.detachBtn = NewFlexButton("", []fyne.Resource{theme.ViewFullScreenIcon(), theme.ViewRestoreIcon()}, false, true, false, false, false, headerSize, 0., mCanvas, func(state int) {
+		if state == 0 {
+			t.Detach()
+		} else {
+			t.Dock()
+		}
+	}, nil, nil, nil, t.detachState, nil)
+	t.mHeader.Add(t.detachBtn)
+	t.detachBtn.Hide()
+
 	t.minimizeBtn = NewFlexButton("", []fyne.Resource{theme.ContentRemoveIcon(), theme.ContentAddIcon()}, false, true, false, false, false, headerSize, 0., mCanvas, nil, nil, nil, nil, t.minimizer, nil)
 	t.minimizeBtn.OnTapped = func(state int) {
 		if state == 1 {
-			//t.mContent.Show()
-			//t.mSeparator.Show()
-			//t.minimized = false
-			t.minimizer.Set(0)
-			//miniButton.SetIcon(theme.ContentRemoveIcon())
+			t.Restore()
 		} else {
-			//t.mContent.Hide()
-			//t.mSeparator.Hide()
-			//t.minimized = true
-			t.minimizer.Set(1)
-			//miniButton.SetIcon(theme.ContentAddIcon())
-		}
-		if t.onMinimize != nil {
-			t.onMinimize(state == 1)
+			t.Minimize()
 		}
-		t.Refresh()
 	}
 	t.mHeader.Add(t.minimizeBtn)
 	if !showMinimize {
@@ -178,24 +252,27 @@ func NewMiniWidget(header string, headerOnTop bool, headerSize float32,
 	}
 
 	t.closeBtn = NewFlexButton("", []fyne.Resource{theme.ContentClearIcon()}, false, true, false, false, false, headerSize, 0., mCanvas, func(int) {
-		//t.Hide()
-		t.closer.Set(true)
-
-		if t.onClose != nil {
-			t.onClose()
-		}
+		t.Close()
 	}, nil, nil, nil, nil, nil)
 	t.mHeader.Add(t.closeBtn)
 	if !showClose {
 		t.closeBtn.Hide()
 	}
 
+	t.mSlot = t.mContent
+	t.collapse = &collapseLayout{ratio: 1., content: func() fyne.CanvasObject { return t.mSlot }}
+	t.mCollapser = container.New(t.collapse, t.mContent)
+	t.mClip = container.NewVScroll(nil)
+	t.mPlaceholder = container.NewCenter(widget.NewLabel("Shown in a separate window"))
 	if headerOnTop {
-		t.widContainer = container.New(&ExpandingLastUnpaddedVBox{}, t.mHeader, t.mSeparator, t.mContent)
+		t.widContainer = container.New(&ExpandingLastUnpaddedVBox{}, t.mHeader, t.mSeparator, t.mCollapser)
 	} else {
-		t.widContainer = container.New(&ExpandingFirstUnpaddedVBox{}, t.mContent, t.mSeparator, t.mHeader)
+		t.widContainer = container.New(&ExpandingFirstUnpaddedVBox{}, t.mCollapser, t.mSeparator, t.mHeader)
 	}
 
+	setFocusGroup(t.mHeader)
+	setFocusGroup(t.mContent)
+
 	t.boxContainer = container.New(&PaddedBox{}, t.mBackground, t.widContainer)
 
 	if shadowed {
@@ -205,9 +282,16 @@ func NewMiniWidget(header string, headerOnTop bool, headerSize float32,
 		t.shadowContainer = t.boxContainer
 	}
 
-	t.mContainer = t.shadowContainer
+	t.mGrips = container.New(&resizeGripLayout{},
+		newMiniWidgetResizeGrip(t, true, false),
+		newMiniWidgetResizeGrip(t, false, true),
+		newMiniWidgetResizeGrip(t, true, true),
+	)
+	t.mGrips.Hide()
+	t.mContainer = container.NewStack(t.shadowContainer, t.mGrips)
 
 	t.DataChanged()
+	t.isReady = true
 
 	return t
 }
@@ -216,8 +300,68 @@ func (t *MiniWidget) CreateRenderer() fyne.WidgetRenderer {
 	return widget.NewSimpleRenderer(t.mContainer)
 }
 
-func (t *MiniWidget) Minimize(state int) {
+// Minimize hides the content, leaving the header only, as the header minimize button
+func (t *MiniWidget) Minimize() {
+	t.minimizer.Set(1)
+	if t.onMinimize != nil {
+		t.onMinimize(false)
+	}
+	t.Refresh()
+}
+
+// Restore shows the content of a minimized MiniWidget, as the header minimize button
+func (t *MiniWidget) Restore() {
+	t.minimizer.Set(0)
+	if t.onMinimize != nil {
+		t.onMinimize(true)
+	}
+	t.Refresh()
+}
+
+// Close hides the MiniWidget, as the header close button
+func (t *MiniWidget) Close() {
+	t.closer.Set(true)
+	if t.onClose != nil {
+		t.onClose()
+	}
+}
+
+// Reopen shows again a closed MiniWidget
+func (t *MiniWidget) Reopen() {
+	t.closer.Set(false)
+}
+
+// IsMinimized returns true if the content is hidden
+func (t *MiniWidget) IsMinimized() bool {
+	d, _ := t.minimizer.Get()
+	return d == 1
+}
 
+// IsClosed returns true if the MiniWidget is closed
+func (t *MiniWidget) IsClosed() bool {
+	d, _ := t.closer.Get()
+	return d
+}
+
+/*
+AddOnStateChanged registers a callback invoked every time the MiniWidget is minimized, restored, closed, reopened,
+detached or docked. Events are raised the same way for header buttons, methods or direct changes of the bindings
+*/
+func (t *MiniWidget) AddOnStateChanged(f func(*MiniWidget, MiniWidgetEvent)) {
+	t.stateLock.Lock()
+	defer t.stateLock.Unlock()
+
+	t.onStateChanged = append(t.onStateChanged, f)
+}
+
+func (t *MiniWidget) notifyState(ev MiniWidgetEvent) {
+	t.stateLock.Lock()
+	callbacks := t.onStateChanged
+	t.stateLock.Unlock()
+
+	for _, f := range callbacks {
+		f(t, ev)
+	}
 }
 
 func (t *MiniWidget) MinSize() fyne.Size {
@@ -246,7 +390,208 @@ func (t *MiniWidget) Refresh() {
 	t.mLabel.Refresh()
 }
 
+// SetIcon defines the icon representing the MiniWidget, e.g. in the icon rail of a collapsed SideBar
+func (t *MiniWidget) SetIcon(icon fyne.Resource) {
+	t.mIcon = icon
+	if t.onIconChanged != nil {
+		t.onIconChanged()
+	}
+}
+
+// Icon returns the icon representing the MiniWidget, nil if not defined
+func (t *MiniWidget) Icon() fyne.Resource {
+	return t.mIcon
+}
+
+/*
+SetBadge shows the Badge on top of the header label, at the Badge corner. The Badge height is relative to the
+header size. A nil Badge removes the current one.
+*/
+func (t *MiniWidget) SetBadge(b *Badge) {
+	if t.mBadge != nil {
+		t.mBadge.mHost = nil
+		if t.mBadge.original != nil {
+			// copies are created for this MiniWidget only
+			t.mBadge.unbind()
+		}
+		t.mBadge = nil
+	}
+	t.mLabelCnt.Objects = []fyne.CanvasObject{t.mLabel}
+	if b != nil {
+		t.mBadge = b
+		t.mLabelCnt.Add(newBadgeHost(b, func() float32 {
+			return t.mSize
+		}))
+	}
+	t.mLabelCnt.Refresh()
+}
+
+// SetDetachable shows or hides the header button moving the content to a separate window and back
+func (t *MiniWidget) SetDetachable(detachable bool) {
+	if detachable {
+		t.detachBtn.Show()
+	} else {
+		t.detachBtn.Hide()
+	}
+	t.mHeader.Refresh()
+}
+
+// SetResizable shows or hides the resize grips on the right and bottom edges and on the bottom-right corner
+func (t *MiniWidget) SetResizable(resizable bool) {
+	if resizable {
+		t.mGrips.Show()
+	} else {
+		t.mGrips.Hide()
+	}
+}
+
+// resizeBy changes the size of the MiniWidget by delta, within MinSize, ResizeMin and ResizeMax
+func (t *MiniWidget) resizeBy(delta fyne.Size) {
+	size := t.Size().Add(delta)
+	size = size.Max(t.MinSize()).Max(t.ResizeMin)
+	if t.ResizeMax.Width > 0 && size.Width > t.ResizeMax.Width {
+		size.Width = t.ResizeMax.Width
+	}
+	if t.ResizeMax.Height > 0 && size.Height > t.ResizeMax.Height {
+		size.Height = t.ResizeMax.Height
+	}
+	if size != t.Size() {
+		t.Resize(size)
+	}
+}
+
+// IsDetached returns true if the content is shown in a separate window
+func (t *MiniWidget) IsDetached() bool {
+	return t.mWindow != nil
+}
+
+/*
+Detach moves the content to a separate window, titled as the header. The MiniWidget keeps its place, showing a
+placeholder, and its minimized state. Closing the window docks the content back. The window is hidden rather than
+closed when docking, so that detaching again shows it with the position and size it had. Like any other window
+of the application, it is closed when the master window is closed.
+If the content is already detached, its window is focused.
+*/
+func (t *MiniWidget) Detach() {
+	if t.mWindow != nil {
+		t.mWindow.RequestFocus()
+		return
+	}
+
+	t.replaceSlot(t.mPlaceholder)
+	t.mContent.Show()
+
+	w := t.hiddenWindow
+	t.hiddenWindow = nil
+	if w == nil {
+		w = fyne.CurrentApp().NewWindow(t.mLabel.mText.Text)
+		w.Resize(t.mContent.MinSize().Max(t.Size()))
+		w.SetCloseIntercept(t.Dock)
+	} else {
+		w.SetTitle(t.mLabel.mText.Text)
+	}
+	w.SetContent(t.mContent)
+	t.mWindow = w
+	w.Show()
+
+	t.detachState.Set(1)
+	t.DataChanged()
+	if t.OnDetachChanged != nil {
+		t.OnDetachChanged(true)
+	}
+	t.notifyState(MiniWidgetDetached)
+}
+
+// Dock moves the content back from its window to the MiniWidget, hiding the window
+func (t *MiniWidget) Dock() {
+	if t.mWindow == nil {
+		return
+	}
+	w := t.mWindow
+	t.mWindow = nil
+
+	w.Hide()
+	w.SetContent(container.NewStack())
+	t.hiddenWindow = w
+
+	t.replaceSlot(t.mContent)
+
+	t.detachState.Set(0)
+	t.DataChanged()
+	if t.OnDetachChanged != nil {
+		t.OnDetachChanged(false)
+	}
+	t.notifyState(MiniWidgetDocked)
+}
+
+// replaceSlot puts obj in place of the current content in the MiniWidget layout, hidden if minimized
+func (t *MiniWidget) replaceSlot(obj fyne.CanvasObject) {
+	t.stopCollapseAnimation()
+	t.mSlot = obj
+	t.applyCollapsed(t.IsMinimized())
+	t.widContainer.Refresh()
+}
+
+/*
+setCollapsed shows or hides the content. When animations are enabled and animate is true, the content height
+is animated, clipping the content while it changes
+*/
+func (t *MiniWidget) setCollapsed(collapsed bool, animate bool) {
+	t.stopCollapseAnimation()
+
+	if !animate || !fyne.CurrentApp().Settings().ShowAnimations() {
+		t.applyCollapsed(collapsed)
+		return
+	}
+
+	t.mSlot.Show()
+	t.mSeparator.Show()
+	t.mClip.Content = t.mSlot
+	t.mCollapser.Objects = []fyne.CanvasObject{t.mClip}
+	t.mClip.Refresh()
+
+	from, to := float32(0.), float32(1.)
+	if collapsed {
+		from, to = 1., 0.
+	}
+	t.collapse.ratio = from
+	t.collapseAnim = fyne.NewAnimation(canvas.DurationStandard, func(done float32) {
+		t.collapse.ratio = from + (to-from)*done
+		t.mCollapser.Refresh()
+		if done >= 1. {
+			t.collapseAnim = nil
+			t.applyCollapsed(collapsed)
+		}
+	})
+	t.collapseAnim.Curve = fyne.AnimationEaseInOut
+	t.collapseAnim.Start()
+}
+
+func (t *MiniWidget) stopCollapseAnimation() {
+	if t.collapseAnim != nil {
+		t.collapseAnim.Stop()
+		t.collapseAnim = nil
+		t.applyCollapsed(t.isMinimized)
+	}
+}
+
+// applyCollapsed shows or hides the content immediately, removing the clipping used by the animation
+func (t *MiniWidget) applyCollapsed(collapsed bool) {
+	t.collapse.ratio = 1.
+	t.mClip.Content = nil
+	t.mCollapser.Objects = []fyne.CanvasObject{t.mSlot}
+	if collapsed {
+		t.mSlot.Hide()
+		t.mSeparator.Hide()
+	} else {
+		t.mSlot.Show()
+		t.mSeparator.Show()
+	}
+	t.mCollapser.Refresh()
+}
+
 func (t *MiniWidget) setShowMore(showMore bool) {
+	t.showMoreButton = showMore
 	if showMore {
 		t.mMoreButton.Show()
 	} else {
@@ -254,6 +599,101 @@ func (t *MiniWidget) setShowMore(showMore bool) {
 	}
 }
 
+/*
+SetHeaderActions defines a toolbar in the header, built from the sub-actions of item: leaves are rendered as small
+buttons, containers as dropdown buttons. Buttons follow the Disabler, Hider and Stater bindings of their ActionItem
+and show the item name as tooltip. When the header is too narrow, the last actions are replaced by an overflow
+button, opening a menu with them. A nil item removes the toolbar.
+*/
+func (t *MiniWidget) SetHeaderActions(item *ActionItem) {
+	for _, o := range t.headerButtons {
+		o.unbind()
+	}
+	if t.overflowMenu != nil {
+		t.overflowMenu.unbind()
+	}
+	for _, o := range t.headerMenus {
+		o.unbind()
+	}
+	t.headerItems, t.headerButtons, t.overflowMenu, t.headerMenus = nil, nil, nil, nil
+	t.mTitleCnt.Objects = t.mTitleCnt.Objects[:2]
+
+	if item != nil {
+		rb := NewRibbonBuilder(t.mSize, t.mSize*.6, nil).withCanvas(t.mCanvas)
+		for _, o := range item.SubActions {
+			var nb *FlexButton
+			if o.Triggered != nil && len(o.SubActions) > 0 {
+				nb = rb.NewSplitButton(o, t.mSize, true)
+			} else if o.Triggered != nil {
+				nb = rb.NewLeafButton(o, t.mSize, true)
+			} else if len(o.SubActions) > 0 || o.AlwaysShowAsContainer {
+				nb = rb.NewDropDownButton(o, t.mSize, true)
+			} else {
+				continue
+			}
+			t.headerItems = append(t.headerItems, o)
+			t.headerButtons = append(t.headerButtons, nb)
+			t.mTitleCnt.Add(nb)
+		}
+		t.headerMenus = rb.takeMenus()
+	}
+
+	setFocusGroup(t.mHeader)
+	t.mHeader.Refresh()
+}
+
+// showOverflowMenu shows the menu of the header actions not fitting the header, built when the overflow button is tapped
+func (t *MiniWidget) showOverflowMenu(items []*ActionItem) {
+	if t.overflowMenu != nil {
+		t.overflowMenu.unbind()
+		t.overflowMenu = nil
+	}
+	if len(items) == 0 {
+		return
+	}
+	t.overflowMenu = NewActionableMenu2(items...)
+	t.overflowMenu.DataChanged()
+	widget.ShowPopUpMenuAtRelativePosition(t.overflowMenu.Menu, t.mCanvas, fyne.NewPos(0., t.overflowButton.Size().Height), t.overflowButton)
+}
+
+// unbind removes the MiniWidget and its header buttons from the listeners of their bindings, so that it can be discarded
+func (t *MiniWidget) unbind() {
+	if t.texter != nil {
+		t.texter.RemoveListener(t)
+	}
+	if t.disabler != nil {
+		t.disabler.RemoveListener(t)
+	}
+	if t.closer != nil {
+		t.closer.RemoveListener(t)
+	}
+	if t.minimizer != nil {
+		t.minimizer.RemoveListener(t)
+	}
+	t.Dock()
+	if t.hiddenWindow != nil {
+		t.hiddenWindow.Close()
+		t.hiddenWindow = nil
+	}
+	for _, o := range []*FlexButton{t.moveUpBtn, t.moveDownBtn, t.mMoreButton, t.overflowButton, t.detachBtn, t.minimizeBtn, t.closeBtn} {
+		if o != nil {
+			o.unbind()
+		}
+	}
+	if t.mBadge != nil {
+		t.mBadge.unbind()
+	}
+	for _, o := range t.headerButtons {
+		o.unbind()
+	}
+	if t.overflowMenu != nil {
+		t.overflowMenu.unbind()
+	}
+	for _, o := range t.headerMenus {
+		o.unbind()
+	}
+}
+
 func (t *MiniWidget) DataChanged() {
 	if t.disabler != nil {
 		d, err := t.disabler.Get()
@@ -273,17 +713,27 @@ func (t *MiniWidget) DataChanged() {
 			} else {
 				t.Show()
 			}
+			if d != t.isClosed {
+				t.isClosed = d
+				if d {
+					t.notifyState(MiniWidgetClosed)
+				} else {
+					t.notifyState(MiniWidgetReopened)
+				}
+			}
 		}
 	}
 
 	if t.minimizer != nil {
 		if d, err := t.minimizer.Get(); err == nil {
-			if d == 1 {
-				t.mContent.Hide()
-				t.mSeparator.Hide()
-			} else {
-				t.mContent.Show()
-				t.mSeparator.Show()
+			if (d == 1) != t.isMinimized {
+				t.isMinimized = d == 1
+				t.setCollapsed(t.isMinimized, t.isReady)
+				if t.isMinimized {
+					t.notifyState(MiniWidgetMinimized)
+				} else {
+					t.notifyState(MiniWidgetRestored)
+				}
 			}
 		}
 	}
@@ -293,6 +743,9 @@ func (t *MiniWidget) DataChanged() {
 			if d, err := t.texter.Get(); err == nil {
 				if t.mLabel.mText.Text != d {
 					t.mLabel.mText.Text = d
+					if t.mWindow != nil {
+						t.mWindow.SetTitle(d)
+					}
 					t.Refresh()
 				}
 			}
@@ -301,3 +754,227 @@ func (t *MiniWidget) DataChanged() {
 
 	t.Refresh()
 }
+
+/*
+miniWidgetDragArea wraps the header label of a MiniWidget, forwarding drag events to the MiniWidget container
+(e.g. a SideBar reordering its children)
+*/
+type miniWidgetDragArea struct {
+	widget.BaseWidget
+
+	mw      *MiniWidget
+	content fyne.CanvasObject
+}
+
+func newMiniWidgetDragArea(mw *MiniWidget, content fyne.CanvasObject) *miniWidgetDragArea {
+	da := &miniWidgetDragArea{mw: mw, content: content}
+	da.ExtendBaseWidget(da)
+	return da
+}
+
+func (da *miniWidgetDragArea) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(da.content)
+}
+
+func (da *miniWidgetDragArea) MinSize() fyne.Size {
+	return da.content.MinSize()
+}
+
+func (da *miniWidgetDragArea) Dragged(ev *fyne.DragEvent) {
+	if da.mw.onDragged != nil {
+		da.mw.onDragged(da.mw, ev)
+	}
+}
+
+func (da *miniWidgetDragArea) DragEnd() {
+	if da.mw.onDragEnd != nil {
+		da.mw.onDragEnd(da.mw)
+	}
+}
+
+// miniWidgetResizeGrip resizes its MiniWidget when dragged, horizontally, vertically or both
+type miniWidgetResizeGrip struct {
+	widget.BaseWidget
+
+	mw                   *MiniWidget
+	horizontal, vertical bool
+	mBackground          *canvas.Rectangle
+}
+
+func newMiniWidgetResizeGrip(mw *MiniWidget, horizontal, vertical bool) *miniWidgetResizeGrip {
+	g := &miniWidgetResizeGrip{
+		mw:          mw,
+		horizontal:  horizontal,
+		vertical:    vertical,
+		mBackground: canvas.NewRectangle(color.Transparent),
+	}
+	g.ExtendBaseWidget(g)
+	g.Refresh()
+	return g
+}
+
+func (g *miniWidgetResizeGrip) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(g.mBackground)
+}
+
+func (g *miniWidgetResizeGrip) Refresh() {
+	if g.horizontal && g.vertical {
+		g.mBackground.FillColor = theme.ShadowColor()
+	}
+	g.mBackground.Refresh()
+}
+
+func (g *miniWidgetResizeGrip) Cursor() desktop.Cursor {
+	if g.horizontal && g.vertical {
+		return desktop.CrosshairCursor
+	} else if g.horizontal {
+		return desktop.HResizeCursor
+	}
+	return desktop.VResizeCursor
+}
+
+func (g *miniWidgetResizeGrip) Dragged(ev *fyne.DragEvent) {
+	delta := fyne.NewSize(0., 0.)
+	if g.horizontal {
+		delta.Width = ev.Dragged.DX
+	}
+	if g.vertical {
+		delta.Height = ev.Dragged.DY
+	}
+	g.mw.resizeBy(delta)
+}
+
+func (g *miniWidgetResizeGrip) DragEnd() {
+	if g.mw.OnResized != nil {
+		g.mw.OnResized(g.mw.Size())
+	}
+}
+
+// resizeGripLayout places the right edge, bottom edge and bottom-right corner resize grips
+type resizeGripLayout struct{}
+
+func (l *resizeGripLayout) MinSize([]fyne.CanvasObject) fyne.Size {
+	return fyne.NewSize(0., 0.)
+}
+
+func (l *resizeGripLayout) Layout(objects []fyne.CanvasObject, containerSize fyne.Size) {
+	if len(objects) != 3 {
+		return
+	}
+	gs := theme.Padding()
+	objects[0].Resize(fyne.NewSize(gs, containerSize.Height-gs))
+	objects[0].Move(fyne.NewPos(containerSize.Width-gs, 0.))
+	objects[1].Resize(fyne.NewSize(containerSize.Width-gs, gs))
+	objects[1].Move(fyne.NewPos(0., containerSize.Height-gs))
+	objects[2].Resize(fyne.NewSize(gs, gs))
+	objects[2].Move(fyne.NewPos(containerSize.Width-gs, containerSize.Height-gs))
+}
+
+/*
+headerTitleLayout lays out the header title (first object), the overflow button (second object) and the header action
+buttons. The title gets its minimum width, the buttons are aligned right in the remaining space and the ones not
+fitting are collapsed, together with the following ones, and replaced by the overflow button. The title also takes
+any space left. The actions collapsed by the last Layout are kept in overflow, for the overflow button menu
+*/
+type headerTitleLayout struct {
+	mw       *MiniWidget
+	overflow []*ActionItem
+}
+
+func (l *headerTitleLayout) MinSize(objects []fyne.CanvasObject) fyne.Size {
+	size := fyne.NewSize(0., 0.)
+	for _, o := range objects {
+		if o.Visible() {
+			size.Height = fyne.Max(size.Height, o.MinSize().Height)
+		}
+	}
+	if len(objects) > 0 {
+		size.Width = objects[0].MinSize().Width
+	}
+	return size
+}
+
+func (l *headerTitleLayout) Layout(objects []fyne.CanvasObject, containerSize fyne.Size) {
+	if len(objects) < 2 {
+		return
+	}
+	overflowBtn := objects[1]
+	buttons := objects[2:]
+	available := containerSize.Width - objects[0].MinSize().Width
+
+	needed := float32(0.)
+	for _, o := range buttons {
+		if o.Visible() {
+			needed += o.MinSize().Width
+		}
+	}
+	if needed > available {
+		// the overflow button takes the room of the last buttons
+		available -= overflowBtn.MinSize().Width
+	}
+
+	used := float32(0.)
+	var overflow []*ActionItem
+	isOverflowing := false
+	kept := make([]fyne.CanvasObject, 0, len(buttons)+1)
+	for i, o := range buttons {
+		if !o.Visible() {
+			continue
+		}
+		w := o.MinSize().Width
+		if !isOverflowing && used+w <= available {
+			used += w
+			kept = append(kept, o)
+			continue
+		}
+		isOverflowing = true
+		o.Resize(fyne.NewSize(0., 0.))
+		o.Move(fyne.NewPos(0., 0.))
+		if i < len(l.mw.headerItems) {
+			overflow = append(overflow, l.mw.headerItems[i])
+		}
+	}
+	if isOverflowing {
+		used += overflowBtn.MinSize().Width
+		kept = append(kept, overflowBtn)
+	} else {
+		overflowBtn.Resize(fyne.NewSize(0., 0.))
+		overflowBtn.Move(fyne.NewPos(0., 0.))
+	}
+	l.overflow = overflow
+
+	x := containerSize.Width - used
+	objects[0].Resize(fyne.NewSize(x, containerSize.Height))
+	objects[0].Move(fyne.NewPos(0., 0.))
+	for _, o := range kept {
+		w := o.MinSize().Width
+		o.Resize(fyne.NewSize(w, containerSize.Height))
+		o.Move(fyne.NewPos(x, 0.))
+		x += w
+	}
+}
+
+/*
+collapseLayout resizes its only object to the container size, while its MinSize is the MinSize of the
+content scaled vertically by ratio. It is used to animate the content height of a MiniWidget
+*/
+type collapseLayout struct {
+	ratio   float32
+	content func() fyne.CanvasObject
+}
+
+func (l *collapseLayout) MinSize([]fyne.CanvasObject) fyne.Size {
+	c := l.content()
+	if c == nil || !c.Visible() {
+		return fyne.NewSize(0., 0.)
+	}
+	size := c.MinSize()
+	return fyne.NewSize(size.Width, size.Height*l.ratio)
+}
+
+func (l *collapseLayout) Layout(objects []fyne.CanvasObject, containerSize fyne.Size) {
+	for _, o := range objects {
+		o.Resize(containerSize)
+		o.Move(fyne.NewPos(0., 0.))
+	}
+}
@@ -0,0 +1,113 @@
+package fyneextensions
+
+import (
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+)
+
+func TestMiniWidgetDetachKeepsMinimized(t *testing.T) {
+	a := test.NewApp()
+	defer a.Quit()
+	w := a.NewWindow("main")
+	defer w.Close()
+
+	mw := newTestMiniWidget("panel", w.Canvas())
+	mw.SetDetachable(true)
+	w.SetContent(mw)
+	w.Resize(fyne.NewSize(300., 300.))
+
+	mw.Minimize()
+	mw.Detach()
+	if !mw.IsDetached() {
+		t.Fatal("MiniWidget is not detached")
+	}
+	if mw.mPlaceholder.Visible() {
+		t.Error("placeholder of a minimized MiniWidget is visible")
+	}
+	if !mw.mContent.Visible() {
+		t.Error("content is hidden in the detached window")
+	}
+	detached := mw.mWindow
+
+	mw.Dock()
+	if mw.IsDetached() {
+		t.Fatal("MiniWidget is still detached")
+	}
+	if !mw.IsMinimized() {
+		t.Error("MiniWidget is not minimized after Dock")
+	}
+	if mw.mContent.Visible() {
+		t.Error("content of a minimized MiniWidget is visible after Dock")
+	}
+
+	mw.Restore()
+	mw.Detach()
+	if mw.mWindow != detached {
+		t.Error("Detach did not reuse the window, losing its position")
+	}
+	mw.Dock()
+	if !mw.mContent.Visible() {
+		t.Error("content of a restored MiniWidget is hidden after Dock")
+	}
+}
+
+func TestMiniWidgetStateEvents(t *testing.T) {
+	a := test.NewApp()
+	defer a.Quit()
+	w := a.NewWindow("main")
+	defer w.Close()
+
+	mw := newTestMiniWidget("panel", w.Canvas())
+	w.SetContent(mw)
+
+	events := make(chan MiniWidgetEvent, 10)
+	mw.AddOnStateChanged(func(_ *MiniWidget, ev MiniWidgetEvent) {
+		events <- ev
+	})
+	// the events are raised by the binding listeners, on the binding goroutine
+	expect := func(want MiniWidgetEvent) {
+		t.Helper()
+		select {
+		case ev := <-events:
+			if ev != want {
+				t.Errorf("event is %v, want %v", ev, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("event %v not raised", want)
+		}
+	}
+
+	mw.Minimize()
+	if !mw.IsMinimized() {
+		t.Error("MiniWidget is not minimized after Minimize")
+	}
+	expect(MiniWidgetMinimized)
+
+	mw.Restore()
+	if mw.IsMinimized() {
+		t.Error("MiniWidget is minimized after Restore")
+	}
+	expect(MiniWidgetRestored)
+
+	mw.Close()
+	if !mw.IsClosed() {
+		t.Error("MiniWidget is not closed after Close")
+	}
+	expect(MiniWidgetClosed)
+
+	mw.Reopen()
+	if mw.IsClosed() {
+		t.Error("MiniWidget is closed after Reopen")
+	}
+	expect(MiniWidgetReopened)
+
+	// setting the binding directly raises the same events
+	mw.minimizer.Set(1)
+	expect(MiniWidgetMinimized)
+	if !mw.IsMinimized() {
+		t.Error("MiniWidget is not minimized after setting its binding")
+	}
+}
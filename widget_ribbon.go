@@ -6,7 +6,7 @@ import (
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/data/binding"
 	"fyne.io/fyne/v2/widget"
-	"math"
+	"slices"
 	"sync"
 )
 
@@ -17,17 +17,21 @@ element that organizes other widgets into a ribbon-like structure.
 It should be added to a container.AppTabs widget. The BuildTabItemRibbon function is a factory function
 for creating new container.TabItem to be added to the container.AppTabs in Fyne.
 
+The ribbon can be horizontal, with groups laid out left-to-right, or vertical, with groups stacked top-to-bottom,
+depending on the RibbonBuilder Orientation. Vertical ribbons should be placed in a container.AppTabs with
+container.TabLocationLeading.
+
 It is based on ActionItem, which dictates the ribbon layout. Items can be laid out horizontally
-or vertically, or in context menus, depending on ActionItem depth and length
+or vertically, or in context menus, depending on ActionItem depth and length and on the RibbonStrategy
+defined for each depth in the RibbonBuilder
 */
 type MainRibbon struct {
 	widget.BaseWidget
 
-	items              []*ActionItem
-	rems               []int
-	canvas             fyne.Canvas
-	maxSize, blockSize float32
-	toolTipper         binding.String
+	items   []*ActionItem
+	rems    []int
+	canvas  fyne.Canvas
+	builder *RibbonBuilder
 
 	mContainer *fyne.Container
 	mMasterCnt fyne.CanvasObject
@@ -37,6 +41,7 @@ type MainRibbon struct {
 	sAllObj       [][]fyne.CanvasObject
 	sMenu         []*ActionableMenu
 	sAllMenuItems [][]*ActionableMenuItem
+	sGroupMenus   [][]*ActionableMenu
 
 	minSize      fyne.Size
 	lastRenderer *mainRibbonRenderer
@@ -64,6 +69,14 @@ func (mr *MainRibbon) MinSize() fyne.Size {
 	return mr.minSize
 }
 
+// mainLength returns the size along the ribbon orientation: width for horizontal ribbons, height for vertical ones
+func (mr *MainRibbon) mainLength(size fyne.Size) float32 {
+	if mr.builder.Orientation == RibbonVertical {
+		return size.Height
+	}
+	return size.Width
+}
+
 type mainRibbonRenderer struct {
 	mRibbon *MainRibbon
 }
@@ -74,7 +87,7 @@ func (mrr *mainRibbonRenderer) Layout(containerSize fyne.Size) {
 	mrr.mRibbon.renderLock.Lock()
 	defer mrr.mRibbon.renderLock.Unlock()
 
-	if containerSize.Width <= 0. {
+	if mrr.mRibbon.mainLength(containerSize) <= 0. {
 		mrr.mRibbon.mMasterCnt.Resize(containerSize)
 		mrr.mRibbon.mMasterCnt.Move(fyne.NewPos(0, 0))
 		mrr.mRibbon.mContainer.Resize(containerSize)
@@ -86,7 +99,7 @@ func (mrr *mainRibbonRenderer) Layout(containerSize fyne.Size) {
 
 	copy(locRems, mrr.mRibbon.rems)
 
-	if containerSize.Width == mrr.mRibbon.mContainer.MinSize().Width {
+	if mrr.mRibbon.mainLength(containerSize) == mrr.mRibbon.mainLength(mrr.mRibbon.mContainer.MinSize()) {
 		mrr.mRibbon.mMasterCnt.Resize(containerSize)
 		mrr.mRibbon.mMasterCnt.Move(fyne.NewPos(0, 0))
 		mrr.mRibbon.mContainer.Resize(containerSize)
@@ -94,7 +107,7 @@ func (mrr *mainRibbonRenderer) Layout(containerSize fyne.Size) {
 		return
 	}
 
-	if containerSize.Width > mrr.mRibbon.mContainer.MinSize().Width {
+	if mrr.mRibbon.mainLength(containerSize) > mrr.mRibbon.mainLength(mrr.mRibbon.mContainer.MinSize()) {
 		sOldZs := make([]fyne.Size, len(mrr.mRibbon.mMiniWidgets))
 		for i, o := range mrr.mRibbon.mMiniWidgets {
 			sOldZs[i] = o.MinSize()
@@ -120,17 +133,17 @@ func (mrr *mainRibbonRenderer) Layout(containerSize fyne.Size) {
 			}
 
 			mrr.mRibbon.sContainer[i].Objects = mrr.mRibbon.sAllObj[i][:len(mrr.mRibbon.sAllObj[i])-locRems[i]]
-			mrr.mRibbon.sMenu[i].mActionableMenuItem.subActionableMenuItems = mrr.mRibbon.sAllMenuItems[i][len(mrr.mRibbon.sAllObj[i])-locRems[i]:]
+			mrr.mRibbon.sMenu[i].mActionableMenuItem.subActionableMenuItems = mrr.mRibbon.overflowMenuItems(i, locRems[i])
 			mrr.mRibbon.canvas.Refresh(mrr.mRibbon.sContainer[i])
 			mrr.mRibbon.sMenu[i].DataChanged()
 
 			sOldZs[i] = mrr.mRibbon.mMiniWidgets[i].MinSize()
-			if mrr.mRibbon.mContainer.MinSize().Width > containerSize.Width {
+			if mrr.mRibbon.mainLength(mrr.mRibbon.mContainer.MinSize()) > mrr.mRibbon.mainLength(containerSize) {
 				break
 			}
 		}
 	}
-	if mrr.mRibbon.mContainer.MinSize().Width > containerSize.Width {
+	if mrr.mRibbon.mainLength(mrr.mRibbon.mContainer.MinSize()) > mrr.mRibbon.mainLength(containerSize) {
 		sOldZs := make([]fyne.Size, len(mrr.mRibbon.mMiniWidgets))
 		for i, o := range mrr.mRibbon.mMiniWidgets {
 			sOldZs[i] = o.MinSize()
@@ -145,7 +158,7 @@ func (mrr *mainRibbonRenderer) Layout(containerSize fyne.Size) {
 			i := 0
 			for i = len(locRems) - 1; i >= 0; i-- {
 				if !locSkip[i] {
-					if locRems[i] < len(mrr.mRibbon.items[i].SubActions)-1 {
+					if locRems[i] < len(mrr.mRibbon.sAllObj[i])-1 {
 						locRems[i] += 1
 						break
 					}
@@ -156,21 +169,21 @@ func (mrr *mainRibbonRenderer) Layout(containerSize fyne.Size) {
 			}
 
 			mrr.mRibbon.sContainer[i].Objects = mrr.mRibbon.sAllObj[i][:len(mrr.mRibbon.sAllObj[i])-locRems[i]]
-			mrr.mRibbon.sMenu[i].mActionableMenuItem.subActionableMenuItems = mrr.mRibbon.sAllMenuItems[i][len(mrr.mRibbon.sAllObj[i])-locRems[i]:]
+			mrr.mRibbon.sMenu[i].mActionableMenuItem.subActionableMenuItems = mrr.mRibbon.overflowMenuItems(i, locRems[i])
 			mrr.mRibbon.canvas.Refresh(mrr.mRibbon.sContainer[i])
 			mrr.mRibbon.sMenu[i].DataChanged()
 
 			if mrr.mRibbon.sAllObj[i][len(mrr.mRibbon.sContainer[i].Objects)-1].Visible() {
-				if mrr.mRibbon.mMiniWidgets[i].MinSize().Width < sOldZs[i].Width {
+				if mrr.mRibbon.mainLength(mrr.mRibbon.mMiniWidgets[i].MinSize()) < mrr.mRibbon.mainLength(sOldZs[i]) {
 					sOldZs[i] = mrr.mRibbon.mMiniWidgets[i].MinSize()
-					if mrr.mRibbon.mContainer.MinSize().Width < containerSize.Width {
+					if mrr.mRibbon.mainLength(mrr.mRibbon.mContainer.MinSize()) < mrr.mRibbon.mainLength(containerSize) {
 						break
 					}
 				} else {
 					locRems[i] -= 1
 					locSkip[i] = true
 					mrr.mRibbon.sContainer[i].Objects = mrr.mRibbon.sAllObj[i][:len(mrr.mRibbon.sAllObj[i])-locRems[i]]
-					mrr.mRibbon.sMenu[i].mActionableMenuItem.subActionableMenuItems = mrr.mRibbon.sAllMenuItems[i][len(mrr.mRibbon.sAllObj[i])-locRems[i]:]
+					mrr.mRibbon.sMenu[i].mActionableMenuItem.subActionableMenuItems = mrr.mRibbon.overflowMenuItems(i, locRems[i])
 					mrr.mRibbon.canvas.Refresh(mrr.mRibbon.sContainer[i])
 					mrr.mRibbon.sMenu[i].DataChanged()
 				}
@@ -211,14 +224,22 @@ The function signature is:
 - toolTipper is a binding.String that serves for adding tooltips to components. This is optional. if set to nil, the tip will be displayed on a context popup instead when passing over a button with the mouse
 */
 func BuildTabItemRibbon(act Actionable, maxSize, blockSize float32, toolTipper binding.String) (*container.TabItem, *MainRibbon) {
+	return BuildTabItemRibbonWithBuilder(act, NewRibbonBuilder(maxSize, blockSize, toolTipper))
+}
+
+/*
+BuildTabItemRibbonWithBuilder works as BuildTabItemRibbon, but the ribbon content is built by the given RibbonBuilder.
+This allows defining a custom RibbonStrategy for each depth of the ActionItem tree.
+*/
+func BuildTabItemRibbonWithBuilder(act Actionable, rb *RibbonBuilder) (*container.TabItem, *MainRibbon) {
 	item := act.GetActions()
 	mCanvas := act.GetCanvas()
 	var mContainer *MainRibbon
 
 	if item.Triggered != nil {
-		mContainer = newMainRibbon([]*ActionItem{item}, mCanvas, maxSize, blockSize, toolTipper)
+		mContainer = newMainRibbon([]*ActionItem{item}, mCanvas, rb)
 	} else if len(item.SubActions) > 0 {
-		mContainer = newMainRibbon(item.SubActions, mCanvas, maxSize, blockSize, toolTipper)
+		mContainer = newMainRibbon(item.SubActions, mCanvas, rb)
 	}
 
 	ribName, _ := item.Name.Get()
@@ -228,98 +249,235 @@ func BuildTabItemRibbon(act Actionable, maxSize, blockSize float32, toolTipper b
 	return retV, mContainer
 }
 
-func newMainRibbon(items []*ActionItem, mCanvas fyne.Canvas, maxSize, blockSize float32, toolTipper binding.String) *MainRibbon {
+func newMainRibbon(items []*ActionItem, mCanvas fyne.Canvas, rb *RibbonBuilder) *MainRibbon {
 	mr := &MainRibbon{
-		items:      items,
-		mContainer: container.NewHBox(),
-		canvas:     mCanvas,
-
-		maxSize:    maxSize,
-		blockSize:  blockSize,
-		toolTipper: toolTipper,
+		canvas:  mCanvas,
+		builder: rb.withCanvas(mCanvas),
 
 		sContainer:    make([]*fyne.Container, 0),
 		sAllObj:       make([][]fyne.CanvasObject, 0),
 		sMenu:         make([]*ActionableMenu, 0),
 		sAllMenuItems: make([][]*ActionableMenuItem, 0),
+		sGroupMenus:   make([][]*ActionableMenu, 0),
 	}
 	mr.ExtendBaseWidget(mr)
 
-	mr.rems = make([]int, len(items))
-	for i := range items {
-		mr.rems[i] = len(items[i].SubActions) - 1
+	if rb.Orientation == RibbonVertical {
+		mr.mContainer = container.NewVBox()
+	} else {
+		mr.mContainer = container.NewHBox()
 	}
 
-	for i, o := range items {
-		rb, sc, sm := buildL1Ribbon(o, mCanvas, maxSize, blockSize, toolTipper)
-		mr.mContainer.Add(rb)
-		mr.mMiniWidgets = append(mr.mMiniWidgets, rb)
-		mr.sContainer = append(mr.sContainer, sc)
-		mr.sAllObj = append(mr.sAllObj, sc.Objects)
-		mr.sMenu = append(mr.sMenu, sm)
-		mr.sAllMenuItems = append(mr.sAllMenuItems, sm.mActionableMenuItem.subActionableMenuItems)
-
-		o.Name.AddListener(mr)
-		o.Hider.AddListener(mr)
-		o.Disabler.AddListener(mr)
-
-		mr.sContainer[i].Objects = mr.sAllObj[i][:len(mr.sAllObj[i])-mr.rems[i]]
-		mr.sMenu[i].mActionableMenuItem.subActionableMenuItems = mr.sAllMenuItems[i][len(mr.sAllObj[i])-mr.rems[i]:]
-		mr.sContainer[i].Refresh()
-		mr.sMenu[i].DataChanged()
+	for _, o := range items {
+		mr.insertGroup(len(mr.items), o)
 	}
 
-	mr.mMasterCnt = container.NewHScroll(mr.mContainer)
+	if rb.Orientation == RibbonVertical {
+		mr.mMasterCnt = container.NewVScroll(mr.mContainer)
+	} else {
+		mr.mMasterCnt = container.NewHScroll(mr.mContainer)
+	}
 
 	mr.minSize = mr.mMasterCnt.MinSize()
 
 	return mr
 }
 
-func (mr *MainRibbon) AddItems(items ...*ActionItem) {
+// insertGroup builds the ribbon group of item and inserts it at index, keeping all the internal slices index-aligned
+func (mr *MainRibbon) insertGroup(index int, item *ActionItem) {
+	mr.builder.takeMenus()
+	mw, sc, sm := buildRibbonGroup(item, mr.builder)
+	menus := mr.builder.takeMenus()
+
+	rem := len(sc.Objects) - 1
+	if rem < 0 {
+		rem = 0
+	}
+
+	mr.items = slices.Insert(mr.items, index, item)
+	mr.rems = slices.Insert(mr.rems, index, rem)
+	mr.mMiniWidgets = slices.Insert(mr.mMiniWidgets, index, mw)
+	mr.sContainer = slices.Insert(mr.sContainer, index, sc)
+	mr.sAllObj = slices.Insert(mr.sAllObj, index, sc.Objects)
+	mr.sMenu = slices.Insert(mr.sMenu, index, sm)
+	mr.sAllMenuItems = slices.Insert(mr.sAllMenuItems, index, sm.mActionableMenuItem.subActionableMenuItems)
+	mr.sGroupMenus = slices.Insert(mr.sGroupMenus, index, menus)
+	mr.mContainer.Objects = slices.Insert(mr.mContainer.Objects, index, fyne.CanvasObject(mw))
+
+	if item.Name != nil {
+		item.Name.AddListener(mr)
+	}
+	if item.Hider != nil {
+		item.Hider.AddListener(mr)
+	}
+	if item.Disabler != nil {
+		item.Disabler.AddListener(mr)
+	}
+
+	mr.sContainer[index].Objects = mr.sAllObj[index][:len(mr.sAllObj[index])-mr.rems[index]]
+	mr.sMenu[index].mActionableMenuItem.subActionableMenuItems = mr.overflowMenuItems(index, mr.rems[index])
+	mr.sContainer[index].Refresh()
+	mr.sMenu[index].DataChanged()
+}
+
+/*
+overflowMenuItems returns the menu items of the last rem objects of the group at index, the ones not fitting the ribbon.
+Groups of an ActionItem with a Triggered function have a single object and no menu item, so none is returned
+*/
+func (mr *MainRibbon) overflowMenuItems(index, rem int) []*ActionableMenuItem {
+	all := mr.sAllMenuItems[index]
+	start := len(mr.sAllObj[index]) - rem
+	if start > len(all) {
+		start = len(all)
+	}
+	return all[start:]
+}
+
+// removeGroup removes the ribbon group at index, removing all the listeners registered by its objects
+func (mr *MainRibbon) removeGroup(index int) {
+	item := mr.items[index]
+	if item.Name != nil {
+		item.Name.RemoveListener(mr)
+	}
+	if item.Hider != nil {
+		item.Hider.RemoveListener(mr)
+	}
+	if item.Disabler != nil {
+		item.Disabler.RemoveListener(mr)
+	}
+
+	mr.mMiniWidgets[index].unbind()
+	unbindRibbonObjects(mr.sAllObj[index])
+	mr.sMenu[index].mActionableMenuItem.subActionableMenuItems = mr.sAllMenuItems[index]
+	mr.sMenu[index].unbind()
+	for _, o := range mr.sGroupMenus[index] {
+		o.unbind()
+	}
+
+	mr.items = slices.Delete(mr.items, index, index+1)
+	mr.rems = slices.Delete(mr.rems, index, index+1)
+	mr.mMiniWidgets = slices.Delete(mr.mMiniWidgets, index, index+1)
+	mr.sContainer = slices.Delete(mr.sContainer, index, index+1)
+	mr.sAllObj = slices.Delete(mr.sAllObj, index, index+1)
+	mr.sMenu = slices.Delete(mr.sMenu, index, index+1)
+	mr.sAllMenuItems = slices.Delete(mr.sAllMenuItems, index, index+1)
+	mr.sGroupMenus = slices.Delete(mr.sGroupMenus, index, index+1)
+	mr.mContainer.Objects = slices.Delete(mr.mContainer.Objects, index, index+1)
+}
+
+// unbind removes all the groups of the MainRibbon and their listeners, so that it can be discarded
+func (mr *MainRibbon) unbind() {
 	mr.renderLock.Lock()
 	defer mr.renderLock.Unlock()
 
-	for i := range items {
-		mr.rems = append(mr.rems, len(items[i].SubActions)-1)
+	for len(mr.items) > 0 {
+		mr.removeGroup(len(mr.items) - 1)
+	}
+}
+
+func unbindRibbonObjects(objects []fyne.CanvasObject) {
+	for _, o := range objects {
+		switch obj := o.(type) {
+		case *FlexButton:
+			obj.unbind()
+		case *fyne.Container:
+			unbindRibbonObjects(obj.Objects)
+		}
+	}
+}
+
+// relayout refreshes the ribbon after its groups are changed
+func (mr *MainRibbon) relayout() {
+	mr.mContainer.Refresh()
+	mr.DataChanged()
+}
+
+// AddItems appends new groups to the MainRibbon, one for each ActionItem
+func (mr *MainRibbon) AddItems(items ...*ActionItem) {
+	mr.renderLock.Lock()
+	for _, o := range items {
+		mr.insertGroup(len(mr.items), o)
+	}
+	mr.renderLock.Unlock()
+
+	mr.relayout()
+}
+
+// RemoveItems removes the groups of the given ActionItem from the MainRibbon. ActionItem which are not groups of the MainRibbon are ignored
+func (mr *MainRibbon) RemoveItems(items ...*ActionItem) {
+	mr.renderLock.Lock()
+	for _, o := range items {
+		if index := slices.Index(mr.items, o); index >= 0 {
+			mr.removeGroup(index)
+		}
 	}
+	mr.renderLock.Unlock()
 
-	oldLen := len(mr.items)
+	mr.relayout()
+}
 
-	for j, o := range items {
-		mr.items = append(mr.items, o)
-		rb, sc, sm := buildL1Ribbon(o, mr.canvas, mr.maxSize, mr.blockSize, mr.toolTipper)
-		mr.mContainer.Add(rb)
-		mr.mMiniWidgets = append(mr.mMiniWidgets, rb)
-		mr.sContainer = append(mr.sContainer, sc)
-		mr.sAllObj = append(mr.sAllObj, sc.Objects)
-		mr.sMenu = append(mr.sMenu, sm)
-		mr.sAllMenuItems = append(mr.sAllMenuItems, sm.mActionableMenuItem.subActionableMenuItems)
+// ReplaceItems replaces the group of oldItem with a new group for newItem, at the same position. It returns false if oldItem is not a group of the MainRibbon
+func (mr *MainRibbon) ReplaceItems(oldItem, newItem *ActionItem) bool {
+	mr.renderLock.Lock()
+	index := slices.Index(mr.items, oldItem)
+	if index >= 0 {
+		mr.removeGroup(index)
+		mr.insertGroup(index, newItem)
+	}
+	mr.renderLock.Unlock()
 
-		o.Name.AddListener(mr)
-		o.Hider.AddListener(mr)
-		o.Disabler.AddListener(mr)
+	if index < 0 {
+		return false
+	}
+	mr.relayout()
+	return true
+}
 
-		i := oldLen + j
-		mr.sContainer[i].Objects = mr.sAllObj[i][:len(mr.sAllObj[i])-mr.rems[i]]
-		mr.sMenu[i].mActionableMenuItem.subActionableMenuItems = mr.sAllMenuItems[i][len(mr.sAllObj[i])-mr.rems[i]:]
-		mr.sContainer[i].Refresh()
-		mr.sMenu[i].DataChanged()
+// SetItems replaces all the groups of the MainRibbon with new groups, one for each ActionItem
+func (mr *MainRibbon) SetItems(items ...*ActionItem) {
+	mr.renderLock.Lock()
+	for len(mr.items) > 0 {
+		mr.removeGroup(len(mr.items) - 1)
+	}
+	for _, o := range items {
+		mr.insertGroup(len(mr.items), o)
 	}
+	mr.renderLock.Unlock()
+
+	mr.relayout()
 }
 
-func buildL1Ribbon(item *ActionItem, mCanvas fyne.Canvas, maxSize, blockSize float32, toolTipper binding.String) (*MiniWidget, *fyne.Container, *ActionableMenu) {
-	mContent := container.New(&ExpandingAllProportionallyPaddedHBox{})
+// Items returns the ActionItem of the MainRibbon groups, in display order
+func (mr *MainRibbon) Items() []*ActionItem {
+	mr.renderLock.Lock()
+	defer mr.renderLock.Unlock()
+
+	return slices.Clone(mr.items)
+}
+
+func buildRibbonGroup(item *ActionItem, rb *RibbonBuilder) (*MiniWidget, *fyne.Container, *ActionableMenu) {
+	mCanvas := rb.Canvas()
+	var mContent *fyne.Container
+	if rb.Orientation == RibbonVertical {
+		mContent = container.NewVBox()
+	} else {
+		mContent = container.New(&ExpandingAllProportionallyPaddedHBox{})
+	}
 
 	var moreMenu *ActionableMenu
 	var moreFunc func(object fyne.CanvasObject)
 
 	if item.Triggered != nil {
-		nb := NewFlexButton("", item.Resources, false, !item.CriticalName, true, false, true, maxSize, blockSize, mCanvas, item.Triggered, item.Name, item.Disabler, item.Hider, item.Stater, toolTipper)
+		hasSubs := len(item.SubActions) > 0
+		nb := rb.setupButton(item, NewFlexButton("", item.Resources, false, !item.CriticalName, true, hasSubs, true, rb.MaxSize, rb.BlockSize, mCanvas, item.Triggered, item.Name, item.Disabler, item.Hider, item.Stater, rb.ToolTipper))
+		if hasSubs {
+			nb.SetSplitMenu(rb.newMenu(item.SubActions...).Menu)
+		}
 		mContent.Add(nb)
+		moreMenu = NewActionableMenu2()
 	} else if len(item.SubActions) > 0 {
 		for _, o := range item.SubActions {
-			mContent.Add(buildL2Ribbon(o, mCanvas, maxSize, blockSize, toolTipper))
+			mContent.Add(rb.Build(o, 0, rb.MaxSize, false))
 		}
 
 		moreActItm := NewActionItem("internal Menu, bug if visible", false, false, item.Resources, false, false, false, 0, nil, item.SubActions)
@@ -331,9 +489,7 @@ func buildL1Ribbon(item *ActionItem, mCanvas fyne.Canvas, maxSize, blockSize flo
 
 	} else {
 		panic(fmt.Errorf("error in ActionItem: nor func nor container"))
-		return nil, nil, nil
 	}
-
 	mwName := ""
 	if item.Name != nil {
 		mwName, _ = item.Name.Get()
@@ -341,7 +497,7 @@ func buildL1Ribbon(item *ActionItem, mCanvas fyne.Canvas, maxSize, blockSize flo
 
 	mw := NewMiniWidget(
 		mwName,
-		false,
+		rb.Orientation == RibbonVertical,
 		20.,
 		mContent,
 		true,
@@ -356,6 +512,10 @@ func buildL1Ribbon(item *ActionItem, mCanvas fyne.Canvas, maxSize, blockSize flo
 		mCanvas,
 	)
 
+	if item.Triggered == nil && item.Badge != nil {
+		mw.SetBadge(item.Badge.copy())
+	}
+
 	if item.Name != nil {
 		item.Name.AddListener(mw)
 	}
@@ -371,94 +531,3 @@ func buildL1Ribbon(item *ActionItem, mCanvas fyne.Canvas, maxSize, blockSize flo
 
 	return mw, mContent, moreMenu
 }
-
-func buildL2Ribbon(item *ActionItem, mCanvas fyne.Canvas, maxSize, blockSize float32, toolTipper binding.String) (mContent fyne.CanvasObject) {
-	if item.Triggered != nil {
-		nb := NewFlexButton("", item.Resources, false, !item.CriticalName, false, false, false, maxSize, blockSize, mCanvas, item.Triggered, item.Name, item.Disabler, item.Hider, item.Stater, toolTipper)
-		mContent = nb
-	} else if item.AlwaysShowAsContainer {
-		nb := NewFlexButton("", item.Resources, false, !item.CriticalName, false, true, true, maxSize, blockSize, mCanvas, func(int) {}, item.Name, item.Disabler, item.Hider, item.Stater, toolTipper)
-		mContent = nb
-
-		sMenu := NewActionableMenu2(item.SubActions...).Menu
-		nb.OnTapped = func(int) {
-			widget.ShowPopUpMenuAtRelativePosition(sMenu, mCanvas, fyne.NewPos(0., nb.Size().Height), nb)
-		}
-	} else if len(item.SubActions) > 0 {
-		maxItems := int(math.Floor(float64(maxSize / blockSize)))
-		if len(item.SubActions) <= maxItems {
-			mContainer := container.New(&EquallySpacedUnpaddedVBox{})
-			for _, o := range item.SubActions {
-				mContainer.Add(buildL3Ribbon(o, mCanvas, maxSize/float32(len(item.SubActions)), blockSize, toolTipper))
-			}
-			mContent = mContainer
-		} else {
-			nb := NewFlexButton("", item.Resources, false, !item.CriticalName, false, true, true, maxSize, blockSize, mCanvas, item.Triggered, item.Name, item.Disabler, item.Hider, item.Stater, toolTipper)
-			mContent = nb
-
-			sMenu := NewActionableMenu2(item.SubActions...).Menu
-			nb.OnTapped = func(int) {
-				widget.ShowPopUpMenuAtRelativePosition(sMenu, mCanvas, fyne.NewPos(0., nb.Size().Height), nb)
-			}
-		}
-	} else {
-		panic(fmt.Errorf("error in ActionItem: nor func nor container"))
-	}
-	/*
-		if item.Hider != nil {
-			if hidden, err := item.Hider.Get(); err == nil {
-				if hidden {
-					mContent.Hide()
-				}
-			}
-		}
-	*/
-	return
-}
-
-func buildL3Ribbon(item *ActionItem, mCanvas fyne.Canvas, maxSize, blockSize float32, toolTipper binding.String) (mContent fyne.CanvasObject) {
-	if item.Triggered != nil {
-		nb := NewFlexButton("", item.Resources, true, !item.CriticalName, false, false, false, maxSize, blockSize, mCanvas, item.Triggered, item.Name, item.Disabler, item.Hider, item.Stater, toolTipper)
-		mContent = nb
-	} else if len(item.SubActions) > 0 {
-		if len(item.SubActions) < 4 {
-			mContainer := container.New(&ExpandingFirstPaddedHBox{})
-			for _, o := range item.SubActions {
-				mContainer.Add(buildL4Ribbon(o, mCanvas, maxSize, blockSize, toolTipper))
-			}
-			mContent = mContainer
-		} else {
-			nb := NewFlexButton("", item.Resources, true, !item.CriticalName, false, true, false, maxSize, blockSize, mCanvas, item.Triggered, item.Name, item.Disabler, item.Hider, item.Stater, toolTipper)
-			mContent = nb
-
-			sMenu := NewActionableMenu2(item.SubActions...).Menu
-
-			nb.OnTapped = func(int) {
-				sPopup := widget.NewPopUpMenu(sMenu, mCanvas)
-				sPopup.ShowAtRelativePosition(fyne.NewPos(nb.Size().Width, 0.), nb)
-			}
-		}
-	} else {
-		panic(fmt.Errorf("error in ActionItem: nor func nor container"))
-	}
-	return
-}
-
-func buildL4Ribbon(item *ActionItem, mCanvas fyne.Canvas, maxSize, blockSize float32, toolTipper binding.String) (mContent fyne.CanvasObject) {
-	if item.Triggered != nil {
-		mContent = NewFlexButton("", item.Resources, true, !item.CriticalName, false, false, false, maxSize, blockSize, mCanvas, item.Triggered, item.Name, item.Disabler, item.Hider, item.Stater, toolTipper)
-	} else if len(item.SubActions) > 0 {
-		nb := NewFlexButton("", item.Resources, true, !item.CriticalName, false, true, false, maxSize, blockSize, mCanvas, item.Triggered, item.Name, item.Disabler, item.Hider, item.Stater, toolTipper)
-		mContent = nb
-
-		sMenu := NewActionableMenu2(item.SubActions...).Menu
-
-		nb.OnTapped = func(int) {
-			sPopup := widget.NewPopUpMenu(sMenu, mCanvas)
-			sPopup.ShowAtRelativePosition(fyne.NewPos(nb.Size().Width, 0.), nb)
-		}
-	} else {
-		panic(fmt.Errorf("error in ActionItem: nor func nor container"))
-	}
-	return
-}
@@ -0,0 +1,59 @@
+package fyneextensions
+
+import (
+	"slices"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+)
+
+func newTestRibbonGroup(name string, leaves ...string) *ActionItem {
+	subs := make([]*ActionItem, 0, len(leaves))
+	for _, o := range leaves {
+		subs = append(subs, NewActionItem(o, false, false, nil, false, false, false, 0, func(int) {}, nil))
+	}
+	return NewActionItem(name, false, false, nil, false, false, false, 0, nil, subs)
+}
+
+func TestMainRibbonInsertRemoveReplace(t *testing.T) {
+	a := test.NewApp()
+	defer a.Quit()
+	w := a.NewWindow("ribbon")
+	defer w.Close()
+
+	home := newTestRibbonGroup("Home", "Cut", "Copy", "Paste")
+	view := newTestRibbonGroup("View", "Zoom")
+	root := NewActionItem("Main", false, false, nil, false, false, false, 0, nil, []*ActionItem{home, view})
+	tab, mr := BuildTabItemRibbon(&testActionable{item: root, mCanvas: w.Canvas()}, 90., 30., nil)
+	w.SetContent(tab.Content)
+	w.Resize(fyne.NewSize(600., 200.))
+
+	// a top-level group with a Triggered function is made of a single button
+	save := NewActionItem("Save", false, false, nil, false, false, false, 0, func(int) {}, nil)
+	mr.AddItems(save)
+	if got := mr.Items(); !slices.Equal(got, []*ActionItem{home, view, save}) {
+		t.Fatalf("groups after insert are %v, want [Home View Save]", got)
+	}
+	w.Resize(fyne.NewSize(80., 200.))
+	w.Resize(fyne.NewSize(600., 200.))
+
+	insert := newTestRibbonGroup("Insert", "Table", "Picture")
+	if !mr.ReplaceItems(view, insert) {
+		t.Fatal("ReplaceItems does not find the View group")
+	}
+	if mr.ReplaceItems(view, insert) {
+		t.Error("ReplaceItems replaces a group which is not in the ribbon")
+	}
+	if got := mr.Items(); !slices.Equal(got, []*ActionItem{home, insert, save}) {
+		t.Fatalf("groups after replace are %v, want [Home Insert Save]", got)
+	}
+
+	mr.RemoveItems(home, save)
+	if got := mr.Items(); !slices.Equal(got, []*ActionItem{insert}) {
+		t.Fatalf("groups after remove are %v, want [Insert]", got)
+	}
+	if len(mr.mMiniWidgets) != 1 || len(mr.sContainer) != 1 || len(mr.sMenu) != 1 || len(mr.rems) != 1 || len(mr.mContainer.Objects) != 1 {
+		t.Error("internal slices are not aligned with the groups after remove")
+	}
+}
@@ -0,0 +1,325 @@
+package fyneextensions
+
+import (
+	"fmt"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/widget"
+	"math"
+)
+
+// RibbonOrientation defines how MainRibbon groups are laid out
+type RibbonOrientation int
+
+const (
+	// RibbonHorizontal lays out groups left-to-right, with the group name below its content
+	RibbonHorizontal RibbonOrientation = iota
+	// RibbonVertical stacks groups top-to-bottom, with the group name above its content
+	RibbonVertical
+)
+
+/*
+RibbonStrategy defines how an ActionItem container (an item with SubActions and no Triggered function)
+is rendered at a given depth of the MainRibbon.
+
+Depth 0 is the depth of the items directly contained in a ribbon group, depth 1 is the depth of their
+sub-actions, and so on. The compact flag tells if the item is rendered in a reduced space (e.g. inside
+a vertical stack), where buttons lay out text and icon horizontally instead of vertically.
+
+Implementations typically call RibbonBuilder.Build for sub-actions, at depth+1, and can rely on the
+RibbonBuilder helpers to create leaf and dropdown buttons.
+*/
+type RibbonStrategy interface {
+	BuildRibbonItem(rb *RibbonBuilder, item *ActionItem, depth int, maxSize float32, compact bool) fyne.CanvasObject
+}
+
+/*
+RibbonBuilder recursively builds the content of MainRibbon groups from an ActionItem tree.
+
+Each depth is rendered with the RibbonStrategy found at the same index in Strategies. When the
+tree is deeper than the defined strategies, the last one is used. Leaves (items with a Triggered function)
+are always rendered as FlexButton, while items with AlwaysShowAsContainer are always rendered as dropdown buttons.
+Orientation defines if the MainRibbon is horizontal (default) or vertical. The default strategies follow it, stacking
+sub-actions across the ribbon and laying rows along it.
+ThemedIcons makes SVG icons follow the theme foreground color, see FlexButton.SetThemedIcons.
+ContextMenu, when defined, returns the menu shown on secondary tap or long press of the button of an ActionItem
+(e.g. with "Add to Quick Access" or "Customize" entries); returning nil shows no menu.
+
+The NewRibbonBuilder factory function should be used to create an instance of RibbonBuilder
+*/
+type RibbonBuilder struct {
+	MaxSize, BlockSize float32
+	ToolTipper         binding.String
+	Strategies         []RibbonStrategy
+	Orientation        RibbonOrientation
+	ThemedIcons        bool
+	ContextMenu        func(*ActionItem) *fyne.Menu
+
+	mCanvas fyne.Canvas
+	mMenus  []*ActionableMenu
+}
+
+/*
+NewRibbonBuilder is the factory function for RibbonBuilder object
+
+it requires the following inputs:
+- maxSize and blockSize: the ribbon height and the height of one line of objects, as in BuildTabItemRibbon.
+In vertical ribbons, maxSize is the height of each item of a group
+- toolTipper: the binding.String used for tooltips. This is optional, see BuildTabItemRibbon
+- strategies: the rendering strategy per depth. If none is defined, the default ones are used:
+VerticalStackStrategy at depth 0, HorizontalRowStrategy at depth 1 and DropDownStrategy from depth 2 onwards
+*/
+func NewRibbonBuilder(maxSize, blockSize float32, toolTipper binding.String, strategies ...RibbonStrategy) *RibbonBuilder {
+	if len(strategies) == 0 {
+		strategies = []RibbonStrategy{
+			&VerticalStackStrategy{},
+			&HorizontalRowStrategy{MaxItems: 3},
+			&DropDownStrategy{},
+		}
+	}
+	return &RibbonBuilder{
+		MaxSize:    maxSize,
+		BlockSize:  blockSize,
+		ToolTipper: toolTipper,
+		Strategies: strategies,
+	}
+}
+
+// Canvas returns the fyne.Canvas the ribbon is rendered on. It is used to show popup menus.
+func (rb *RibbonBuilder) Canvas() fyne.Canvas {
+	return rb.mCanvas
+}
+
+func (rb *RibbonBuilder) withCanvas(mCanvas fyne.Canvas) *RibbonBuilder {
+	nb := *rb
+	nb.mCanvas = mCanvas
+	return &nb
+}
+
+// newMenu creates the ActionableMenu of a dropdown or split button, keeping track of it so that it can be unbound
+func (rb *RibbonBuilder) newMenu(items ...*ActionItem) *ActionableMenu {
+	am := NewActionableMenu2(items...)
+	rb.mMenus = append(rb.mMenus, am)
+	return am
+}
+
+// takeMenus returns the menus created since the last call, so that their owner can unbind them when discarded
+func (rb *RibbonBuilder) takeMenus() []*ActionableMenu {
+	menus := rb.mMenus
+	rb.mMenus = nil
+	return menus
+}
+
+// StrategyAt returns the RibbonStrategy used at the given depth
+func (rb *RibbonBuilder) StrategyAt(depth int) RibbonStrategy {
+	if len(rb.Strategies) == 0 {
+		return &DropDownStrategy{}
+	}
+	if depth < 0 {
+		depth = 0
+	}
+	if depth >= len(rb.Strategies) {
+		depth = len(rb.Strategies) - 1
+	}
+	return rb.Strategies[depth]
+}
+
+/*
+Build renders an ActionItem at the given depth within maxSize height. Leaves are rendered as
+FlexButton, containers are rendered by the strategy defined for the depth. Items with both a function
+and sub-actions are rendered as split buttons.
+*/
+func (rb *RibbonBuilder) Build(item *ActionItem, depth int, maxSize float32, compact bool) fyne.CanvasObject {
+	if item.Triggered != nil && len(item.SubActions) > 0 {
+		return rb.NewSplitButton(item, maxSize, compact)
+	} else if item.Triggered != nil {
+		return rb.NewLeafButton(item, maxSize, compact)
+	} else if item.AlwaysShowAsContainer {
+		return rb.NewDropDownButton(item, maxSize, compact)
+	} else if len(item.SubActions) > 0 {
+		return rb.StrategyAt(depth).BuildRibbonItem(rb, item, depth, maxSize, compact)
+	}
+	panic(fmt.Errorf("error in ActionItem: nor func nor container"))
+}
+
+// NewLeafButton creates the FlexButton triggering the ActionItem function
+func (rb *RibbonBuilder) NewLeafButton(item *ActionItem, maxSize float32, compact bool) *FlexButton {
+	return rb.setupButton(item, NewFlexButton("", item.Resources, compact, !item.CriticalName, false, false, false, maxSize, rb.BlockSize, rb.mCanvas, item.Triggered, item.Name, item.Disabler, item.Hider, item.Stater, rb.ToolTipper))
+}
+
+/*
+NewDropDownButton creates a FlexButton with a "more" icon, which opens a popup menu of the ActionItem sub-actions.
+When not compact the menu is shown below the button, otherwise side of it.
+*/
+func (rb *RibbonBuilder) NewDropDownButton(item *ActionItem, maxSize float32, compact bool) *FlexButton {
+	nb := rb.setupButton(item, NewFlexButton("", item.Resources, compact, !item.CriticalName, false, true, !compact, maxSize, rb.BlockSize, rb.mCanvas, nil, item.Name, item.Disabler, item.Hider, item.Stater, rb.ToolTipper))
+
+	sMenu := rb.newMenu(item.SubActions...).Menu
+	nb.OnTapped = func(int) {
+		if compact {
+			widget.ShowPopUpMenuAtRelativePosition(sMenu, rb.mCanvas, fyne.NewPos(nb.Size().Width, 0.), nb)
+		} else {
+			widget.ShowPopUpMenuAtRelativePosition(sMenu, rb.mCanvas, fyne.NewPos(0., nb.Size().Height), nb)
+		}
+	}
+	return nb
+}
+
+/*
+NewSplitButton creates a FlexButton in split mode: tapping the main area triggers the ActionItem function,
+while tapping the "more" icon opens a popup menu of the ActionItem sub-actions.
+When not compact the "more" icon is shown below the button, otherwise side of it.
+*/
+func (rb *RibbonBuilder) NewSplitButton(item *ActionItem, maxSize float32, compact bool) *FlexButton {
+	return rb.newSplitButton(item, item.Triggered, maxSize, compact)
+}
+
+func (rb *RibbonBuilder) newSplitButton(item *ActionItem, f func(int), maxSize float32, compact bool) *FlexButton {
+	nb := rb.setupButton(item, NewFlexButton("", item.Resources, compact, !item.CriticalName, false, true, !compact, maxSize, rb.BlockSize, rb.mCanvas, f, item.Name, item.Disabler, item.Hider, item.Stater, rb.ToolTipper))
+	nb.SetSplitMenu(rb.newMenu(item.SubActions...).Menu)
+	return nb
+}
+
+/*
+setupButton completes a FlexButton built for the ActionItem: it applies the ActionItem Importance and disabled
+resources, the RibbonBuilder ThemedIcons, shows a copy of the ActionItem Badge,
+calls DoubleTriggered on double tap and shows the ContextMenu on secondary tap or long press
+*/
+func (rb *RibbonBuilder) setupButton(item *ActionItem, nb *FlexButton) *FlexButton {
+	if item.Importance != widget.MediumImportance {
+		nb.SetImportance(item.Importance)
+	}
+	if rb.ThemedIcons {
+		nb.SetThemedIcons(true)
+	}
+	if len(item.DisabledResources) > 0 {
+		nb.SetDisabledResources(item.DisabledResources...)
+	}
+	if item.Badge != nil {
+		nb.SetBadge(item.Badge.copy())
+	}
+	if item.DoubleTriggered != nil {
+		nb.OnDoubleTapped = func(state int, _ *fyne.PointEvent) {
+			item.DoubleTriggered(state)
+		}
+	}
+	if rb.ContextMenu != nil {
+		showMenu := func(_ int, ev *fyne.PointEvent) {
+			if menu := rb.ContextMenu(item); menu != nil && rb.mCanvas != nil {
+				widget.ShowPopUpMenuAtPosition(menu, rb.mCanvas, ev.AbsolutePosition)
+			}
+		}
+		nb.OnSecondaryTapped = showMenu
+		nb.OnLongPressed = showMenu
+	}
+	return nb
+}
+
+/*
+VerticalStackStrategy renders sub-actions stacked across the ribbon: top-to-bottom, sharing equally the available height,
+in horizontal ribbons, and side by side, each with the available height, in vertical ribbons.
+Sub-actions are rendered compact in horizontal ribbons. If the number of sub-actions exceeds MaxItems when greater than zero,
+or otherwise the lines available (maxSize / blockSize), the item is rendered as a dropdown button instead.
+*/
+type VerticalStackStrategy struct {
+	MaxItems int
+}
+
+func (s *VerticalStackStrategy) BuildRibbonItem(rb *RibbonBuilder, item *ActionItem, depth int, maxSize float32, compact bool) fyne.CanvasObject {
+	maxItems := s.MaxItems
+	if maxItems <= 0 {
+		maxItems = int(math.Floor(float64(maxSize / rb.BlockSize)))
+	}
+	if len(item.SubActions) > maxItems {
+		return rb.NewDropDownButton(item, maxSize, compact)
+	}
+
+	if rb.Orientation == RibbonVertical {
+		mContainer := container.New(&EquallySpacedUnpaddedHBox{})
+		for _, o := range item.SubActions {
+			mContainer.Add(rb.Build(o, depth+1, maxSize, compact))
+		}
+		return mContainer
+	}
+
+	mContainer := container.New(&EquallySpacedUnpaddedVBox{})
+	for _, o := range item.SubActions {
+		mContainer.Add(rb.Build(o, depth+1, maxSize/float32(len(item.SubActions)), true))
+	}
+	return mContainer
+}
+
+/*
+HorizontalRowStrategy renders sub-actions in a row along the ribbon: side by side, with the same height of the row,
+in horizontal ribbons, and top-to-bottom, sharing equally the available height, in vertical ribbons.
+If the number of sub-actions exceeds MaxItems, the item is rendered as a dropdown button instead.
+A MaxItems lower or equal to zero means no limit in horizontal ribbons, and the lines available (maxSize / blockSize)
+in vertical ribbons.
+*/
+type HorizontalRowStrategy struct {
+	MaxItems int
+}
+
+func (s *HorizontalRowStrategy) BuildRibbonItem(rb *RibbonBuilder, item *ActionItem, depth int, maxSize float32, compact bool) fyne.CanvasObject {
+	if rb.Orientation == RibbonVertical {
+		maxItems := s.MaxItems
+		if lines := int(math.Floor(float64(maxSize / rb.BlockSize))); maxItems <= 0 || maxItems > lines {
+			maxItems = lines
+		}
+		if len(item.SubActions) > maxItems {
+			return rb.NewDropDownButton(item, maxSize, compact)
+		}
+
+		mContainer := container.New(&EquallySpacedUnpaddedVBox{})
+		for _, o := range item.SubActions {
+			mContainer.Add(rb.Build(o, depth+1, maxSize/float32(len(item.SubActions)), true))
+		}
+		return mContainer
+	}
+
+	if s.MaxItems > 0 && len(item.SubActions) > s.MaxItems {
+		return rb.NewDropDownButton(item, maxSize, compact)
+	}
+
+	mContainer := container.New(&ExpandingFirstPaddedHBox{})
+	for _, o := range item.SubActions {
+		mContainer.Add(rb.Build(o, depth+1, maxSize, compact))
+	}
+	return mContainer
+}
+
+// DropDownStrategy renders the item as a button opening a popup menu with all its sub-actions
+type DropDownStrategy struct{}
+
+func (s *DropDownStrategy) BuildRibbonItem(rb *RibbonBuilder, item *ActionItem, depth int, maxSize float32, compact bool) fyne.CanvasObject {
+	return rb.NewDropDownButton(item, maxSize, compact)
+}
+
+/*
+SplitButtonStrategy renders the item as a split button: the main area executes the default action, the "more"
+icon opens a popup menu with all the sub-actions. The default action is the first sub-action triggering
+a function. If no sub-action can be triggered, the item is rendered as a dropdown button.
+*/
+type SplitButtonStrategy struct{}
+
+func (s *SplitButtonStrategy) BuildRibbonItem(rb *RibbonBuilder, item *ActionItem, depth int, maxSize float32, compact bool) fyne.CanvasObject {
+	var defItem *ActionItem
+	for _, o := range item.SubActions {
+		if o.Triggered != nil {
+			defItem = o
+			break
+		}
+	}
+	if defItem == nil {
+		return rb.NewDropDownButton(item, maxSize, compact)
+	}
+
+	return rb.newSplitButton(item, func(int) {
+		state := 0
+		if defItem.Stater != nil {
+			state, _ = defItem.Stater.Get()
+		}
+		defItem.Triggered(state)
+	}, maxSize, compact)
+}
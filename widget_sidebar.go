@@ -1,24 +1,86 @@
 package fyneextensions
 
 import (
+	"encoding/json"
+	"fmt"
 	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 )
 
 // SideBar is a Fyne compatible widget which acts as a container for MiniWidget components.
-// It can manage basic functionalities such as adding content to the sidebar and moving child objects up and down
+// It can manage basic functionalities such as adding content to the sidebar and moving child objects up and down.
+// Children can also be reordered by dragging their header: a line shows the drop position and, when the SideBar
+// is placed in a container.Scroll defined as ScrollContainer, the content scrolls while dragging near its edges.
+// OnReordered is called with the new order every time children are moved.
+// The container tree is rebuilt only when the grouping of children changes, and the offsets of the splits are kept
+// across rebuilds, so that minimizing or restoring a child does not reset the layout.
+// Children can also be grouped as an accordion or in tabs, see SetMode and GroupTabs.
 type SideBar struct {
 	widget.BaseWidget
 
-	mContent *fyne.Container
-	mObjects []*MiniWidget
+	mContent   *fyne.Container
+	mTree      *fyne.Container
+	mDropLayer *fyne.Container
+	mDropLine  *canvas.Rectangle
+	mObjects   []*MiniWidget
+	mChildren  map[*MiniWidget]*sideBarChild
+
+	dropIndex int
+
+	structure      string
+	mSplits        map[*MiniWidget]*container.Split
+	pendingOffsets map[string]float64
+	defaults       []sideBarPanelState
+
+	mViewAction *ActionItem
+	mViewPanels *ActionItem
+
+	mRail         *fyne.Container
+	railButtons   []*FlexButton
+	railStructure string
+	isCollapsed   bool
+	isNarrow      bool
+	mFlyout       *sideBarFlyout
+
+	mode            SideBarMode
+	mTabs           map[int]*container.AppTabs
+	nextTabGroup    int
+	pendingSelected map[string]bool
+	lastExpanded    map[*MiniWidget]bool
+
+	ScrollContainer *container.Scroll
+	OnReordered     func([]*MiniWidget)
+
+	// CollapseWidth is the width below which the SideBar collapses to the icon rail, and above which it expands. Zero disables it
+	CollapseWidth float32
+	// RailSize is the size of the icon rail buttons
+	RailSize float32
+	// FlyoutOnClick defines if a click on a rail icon shows the child in a flyout (true) or expands the SideBar (false)
+	FlyoutOnClick bool
+	// FlyoutWidth is the width of the flyout, the child MinSize width is used when zero
+	FlyoutWidth float32
+	// OnCollapsedChanged is called when the SideBar collapses to the icon rail (true) or expands (false)
+	OnCollapsedChanged func(collapsed bool)
 }
 
 func NewSideBar(widgets ...*MiniWidget) *SideBar {
 	sb := &SideBar{
-		mContent: container.NewStack(),
+		mTree:      container.NewStack(),
+		mDropLayer: container.NewWithoutLayout(),
+		mDropLine:  canvas.NewRectangle(theme.FocusColor()),
+		dropIndex:  -1,
+		mSplits:    map[*MiniWidget]*container.Split{},
+		mChildren:  map[*MiniWidget]*sideBarChild{},
+		mRail:      container.NewVBox(),
+		RailSize:   theme.IconInlineSize() * 1.5,
 	}
+	sb.mDropLine.Hide()
+	sb.mDropLayer.Add(sb.mDropLine)
+	sb.mRail.Hide()
+	sb.mContent = container.NewStack(sb.mTree, sb.mDropLayer, sb.mRail)
 
 	sb.ExtendBaseWidget(sb)
 
@@ -31,21 +93,175 @@ func (sb *SideBar) DataChanged() {
 	sb.Refresh()
 }
 
+// AddWidget appends widgets to the children of the SideBar
 func (sb *SideBar) AddWidget(widgets ...*MiniWidget) {
+	sb.InsertWidget(len(sb.mObjects), widgets...)
+}
+
+// InsertWidget inserts widgets in the children of the SideBar, starting at position at. Children already in the SideBar are ignored
+func (sb *SideBar) InsertWidget(at int, widgets ...*MiniWidget) {
+	if at < 0 {
+		at = 0
+	}
+	if at > len(sb.mObjects) {
+		at = len(sb.mObjects)
+	}
+
+	added := make([]*MiniWidget, 0, len(widgets))
 	for _, o := range widgets {
-		sb.mObjects = append(sb.mObjects, o)
+		if sb.IndexOf(o) >= 0 || sb.isAdded(o, added) {
+			continue
+		}
+		sb.register(o)
+		added = append(added, o)
+	}
+	if len(added) == 0 {
+		return
+	}
+
+	sb.mObjects = append(sb.mObjects[:at], append(added, sb.mObjects[at:]...)...)
+	sb.Refresh()
+}
+
+func (sb *SideBar) isAdded(mw *MiniWidget, added []*MiniWidget) bool {
+	for _, o := range added {
+		if o == mw {
+			return true
+		}
+	}
+	return false
+}
+
+// RemoveWidget removes widgets from the children of the SideBar, restoring their header buttons and removing the SideBar listeners
+func (sb *SideBar) RemoveWidget(widgets ...*MiniWidget) {
+	removed := false
+	for _, o := range widgets {
+		i := sb.IndexOf(o)
+		if i < 0 {
+			continue
+		}
+		sb.mObjects = append(sb.mObjects[:i], sb.mObjects[i+1:]...)
+		sb.unregister(o)
+		removed = true
+	}
+	if removed {
+		sb.Refresh()
+	}
+}
+
+// MoveTo moves mw to position index of the children, clamped to the valid range
+func (sb *SideBar) MoveTo(mw *MiniWidget, index int) {
+	i := sb.IndexOf(mw)
+	if i < 0 {
+		return
+	}
+	if index < 0 {
+		index = 0
+	}
+	if index > len(sb.mObjects)-1 {
+		index = len(sb.mObjects) - 1
+	}
+	if index == i {
+		return
+	}
 
-		o.minimizeBtn.Show()
-		o.minimizer.AddListener(sb)
-		o.closer.AddListener(sb)
+	sb.mObjects = append(sb.mObjects[:i], sb.mObjects[i+1:]...)
+	sb.mObjects = append(sb.mObjects[:index], append([]*MiniWidget{mw}, sb.mObjects[index:]...)...)
+	sb.notifyReordered()
+	sb.Refresh()
+}
 
-		o.moveUpBtn.Show()
-		o.onMoveUp = sb.moveUpChild
+// Widgets returns the children of the SideBar in their current order, including the closed ones
+func (sb *SideBar) Widgets() []*MiniWidget {
+	return append([]*MiniWidget{}, sb.mObjects...)
+}
 
-		o.moveDownBtn.Show()
-		o.onMoveDown = sb.moveDownChild
+// IndexOf returns the position of mw in the children of the SideBar, or -1 if it is not a child
+func (sb *SideBar) IndexOf(mw *MiniWidget) int {
+	for i, o := range sb.mObjects {
+		if o == mw {
+			return i
+		}
 	}
-	//sb.Refresh()
+	return -1
+}
+
+// sideBarChild keeps what the SideBar changes on a child when it is added, to restore it when it is removed
+type sideBarChild struct {
+	showMinimize bool
+	showMoveUp   bool
+	showMoveDown bool
+	onMoveUp     func(*MiniWidget)
+	onMoveDown   func(*MiniWidget)
+	toggle       *sideBarPanelToggle
+	tabGroup     int
+}
+
+func (sb *SideBar) register(o *MiniWidget) {
+	sb.mChildren[o] = &sideBarChild{
+		showMinimize: o.minimizeBtn.Visible(),
+		showMoveUp:   o.moveUpBtn.Visible(),
+		showMoveDown: o.moveDownBtn.Visible(),
+		onMoveUp:     o.onMoveUp,
+		onMoveDown:   o.onMoveDown,
+	}
+	sb.defaults = append(sb.defaults, sideBarPanelState{mw: o, minimized: o.IsMinimized(), closed: o.IsClosed()})
+	if sb.mViewPanels != nil {
+		sb.addToggle(o)
+	}
+
+	o.minimizeBtn.Show()
+	o.minimizer.AddListener(sb)
+	o.closer.AddListener(sb)
+	if o.texter != nil {
+		o.texter.AddListener(sb)
+	}
+
+	o.moveUpBtn.Show()
+	o.onMoveUp = sb.moveUpChild
+
+	o.moveDownBtn.Show()
+	o.onMoveDown = sb.moveDownChild
+
+	o.onDragged = sb.dragChild
+	o.onDragEnd = sb.dropChild
+	o.onIconChanged = sb.iconChanged
+}
+
+func (sb *SideBar) unregister(o *MiniWidget) {
+	o.minimizer.RemoveListener(sb)
+	o.closer.RemoveListener(sb)
+	if o.texter != nil {
+		o.texter.RemoveListener(sb)
+	}
+	o.onDragged = nil
+	o.onDragEnd = nil
+	o.onIconChanged = nil
+	if sb.mFlyout != nil && sb.mFlyout.mw == o {
+		sb.hideFlyout()
+	}
+
+	if c, ok := sb.mChildren[o]; ok {
+		o.minimizeBtn.Hidden = !c.showMinimize
+		o.moveUpBtn.Hidden = !c.showMoveUp
+		o.moveDownBtn.Hidden = !c.showMoveDown
+		o.onMoveUp = c.onMoveUp
+		o.onMoveDown = c.onMoveDown
+		if c.toggle != nil {
+			o.closer.RemoveListener(c.toggle)
+			sb.mViewPanels.RemoveActions(c.toggle.mItem)
+		}
+		delete(sb.mChildren, o)
+	}
+	o.Refresh()
+
+	for i, d := range sb.defaults {
+		if d.mw == o {
+			sb.defaults = append(sb.defaults[:i], sb.defaults[i+1:]...)
+			break
+		}
+	}
+	delete(sb.mSplits, o)
 }
 
 func (sb *SideBar) moveUpChild(mw *MiniWidget) {
@@ -60,6 +276,7 @@ func (sb *SideBar) moveUpChild(mw *MiniWidget) {
 
 	if itmNum > 0 {
 		sb.mObjects[itmNum-1], sb.mObjects[itmNum] = sb.mObjects[itmNum], sb.mObjects[itmNum-1]
+		sb.notifyReordered()
 	}
 
 	sb.Refresh()
@@ -77,10 +294,167 @@ func (sb *SideBar) moveDownChild(mw *MiniWidget) {
 
 	if itmNum >= 0 && itmNum < len(sb.mObjects)-1 {
 		sb.mObjects[itmNum+1], sb.mObjects[itmNum] = sb.mObjects[itmNum], sb.mObjects[itmNum+1]
+		sb.notifyReordered()
 	}
 	sb.Refresh()
 }
 
+func (sb *SideBar) notifyReordered() {
+	if sb.OnReordered != nil {
+		sb.OnReordered(append([]*MiniWidget{}, sb.mObjects...))
+	}
+}
+
+// visibleChildren returns the children which are not closed, in order. The child shown in the flyout is excluded
+func (sb *SideBar) visibleChildren() []*MiniWidget {
+	visibleObj := make([]*MiniWidget, 0)
+	for _, o := range sb.mObjects {
+		if sb.mFlyout != nil && sb.mFlyout.mw == o {
+			continue
+		}
+		if closed, _ := o.closer.Get(); !closed {
+			visibleObj = append(visibleObj, o)
+		}
+	}
+	return visibleObj
+}
+
+// dragChild updates the drop line position while a child header is dragged, and scrolls when needed
+func (sb *SideBar) dragChild(mw *MiniWidget, ev *fyne.DragEvent) {
+	if sb.isCollapsed || sb.mode == SideBarTabbed {
+		return
+	}
+	sb.autoScroll(ev.AbsolutePosition)
+
+	d := fyne.CurrentApp().Driver()
+	sbPos := d.AbsolutePositionForObject(sb)
+	visibleObj := sb.visibleChildren()
+	if len(visibleObj) == 0 {
+		return
+	}
+
+	sb.dropIndex = len(visibleObj)
+	for i, o := range visibleObj {
+		oPos := d.AbsolutePositionForObject(o)
+		if ev.AbsolutePosition.Y < oPos.Y+o.Size().Height/2. {
+			sb.dropIndex = i
+			break
+		}
+	}
+
+	lineY := float32(0.)
+	if sb.dropIndex < len(visibleObj) {
+		lineY = d.AbsolutePositionForObject(visibleObj[sb.dropIndex]).Y - sbPos.Y - theme.Padding()/2.
+	} else {
+		last := visibleObj[len(visibleObj)-1]
+		lineY = d.AbsolutePositionForObject(last).Y - sbPos.Y + last.Size().Height + theme.Padding()/2.
+	}
+	lineHeight := float32(2.)
+	if lineY < 0 {
+		lineY = 0
+	} else if lineY > sb.Size().Height-lineHeight {
+		lineY = sb.Size().Height - lineHeight
+	}
+
+	sb.mDropLine.FillColor = theme.FocusColor()
+	sb.mDropLine.Resize(fyne.NewSize(sb.Size().Width, lineHeight))
+	sb.mDropLine.Move(fyne.NewPos(0., lineY-lineHeight/2.))
+	sb.mDropLine.Show()
+	sb.mDropLayer.Refresh()
+}
+
+// autoScroll scrolls ScrollContainer, if defined, when the pointer is close to its visible edges
+func (sb *SideBar) autoScroll(pointer fyne.Position) {
+	if sb.ScrollContainer == nil {
+		return
+	}
+	sc := sb.ScrollContainer
+	scPos := fyne.CurrentApp().Driver().AbsolutePositionForObject(sc)
+	edge := theme.IconInlineSize() * 2.
+	step := theme.IconInlineSize() / 2.
+
+	offset := sc.Offset
+	if pointer.Y < scPos.Y+edge {
+		offset.Y -= step
+	} else if pointer.Y > scPos.Y+sc.Size().Height-edge {
+		offset.Y += step
+	} else {
+		return
+	}
+	maxY := sc.Content.MinSize().Height - sc.Size().Height
+	if offset.Y > maxY {
+		offset.Y = maxY
+	}
+	if offset.Y < 0 {
+		offset.Y = 0
+	}
+	if offset != sc.Offset {
+		sc.Offset = offset
+		sc.Refresh()
+	}
+}
+
+// cancelDrag hides the drop line, e.g. when a child is dragged out of the SideBar
+func (sb *SideBar) cancelDrag() {
+	sb.dropIndex = -1
+	if sb.mDropLine.Visible() {
+		sb.mDropLine.Hide()
+		sb.mDropLayer.Refresh()
+	}
+}
+
+// dropChild moves the dragged child to the drop position
+func (sb *SideBar) dropChild(mw *MiniWidget) {
+	sb.mDropLine.Hide()
+	sb.mDropLayer.Refresh()
+
+	visibleObj := sb.visibleChildren()
+	dropIndex := sb.dropIndex
+	sb.dropIndex = -1
+	if dropIndex < 0 || dropIndex > len(visibleObj) {
+		return
+	}
+
+	// the child is inserted before the visible child at dropIndex, or after the last visible one
+	var before *MiniWidget
+	if dropIndex < len(visibleObj) {
+		before = visibleObj[dropIndex]
+	}
+	if before == mw {
+		return
+	}
+
+	oldOrder := append([]*MiniWidget{}, sb.mObjects...)
+	newOrder := make([]*MiniWidget, 0, len(sb.mObjects))
+	for _, o := range sb.mObjects {
+		if o == mw {
+			continue
+		}
+		if o == before {
+			newOrder = append(newOrder, mw)
+		}
+		newOrder = append(newOrder, o)
+	}
+	if before == nil {
+		newOrder = append(newOrder, mw)
+	}
+
+	changed := false
+	for i := range newOrder {
+		if newOrder[i] != oldOrder[i] {
+			changed = true
+			break
+		}
+	}
+	if !changed {
+		return
+	}
+
+	sb.mObjects = newOrder
+	sb.notifyReordered()
+	sb.Refresh()
+}
+
 func (sb *SideBar) CreateRenderer() fyne.WidgetRenderer {
 	return widget.NewSimpleRenderer(sb.mContent)
 }
@@ -90,13 +464,36 @@ func (sb *SideBar) MinSize() fyne.Size {
 }
 
 func (sb *SideBar) Refresh() {
-	sb.mContent.RemoveAll()
-	visibleObj := make([]*MiniWidget, 0)
-	for _, o := range sb.mObjects {
-		if closed, _ := o.closer.Get(); !closed {
-			visibleObj = append(visibleObj, o)
+	if sb.mFlyout != nil && sb.mFlyout.mw.IsClosed() {
+		sb.hideFlyout()
+		return
+	}
+	sb.refreshRail()
+	if sb.mode == SideBarAccordion && sb.applyAccordion() {
+		// the children minimized by the accordion refresh the SideBar again
+		return
+	}
+
+	groups, lastGpAllMin := sb.groups()
+
+	structure := sb.structureOf(groups, lastGpAllMin)
+	if structure == sb.structure && len(sb.mTree.Objects) > 0 {
+		if sb.mode == SideBarTabbed {
+			sb.refreshTabTitles()
 		}
+		sb.mTree.Refresh()
+		return
 	}
+	sb.structure = structure
+	sb.rebuild(groups, lastGpAllMin)
+}
+
+/*
+groups splits the visible children in groups, each ending with a child which is not minimized (the last one can be
+made of minimized children only). It also returns if all the children of the last group are minimized
+*/
+func (sb *SideBar) groups() ([][]*MiniWidget, bool) {
+	visibleObj := sb.visibleChildren()
 
 	groups := make([][]*MiniWidget, 0)
 	groups = append(groups, make([]*MiniWidget, 0))
@@ -108,25 +505,84 @@ func (sb *SideBar) Refresh() {
 			cGp++
 		}
 	}
+	if len(groups[len(groups)-1]) == 0 {
+		groups = groups[:len(groups)-1]
+	}
 
 	lastGpAllMin := true
-	var top fyne.CanvasObject
-	var topCnt *fyne.Container
-	var btm fyne.CanvasObject
-	var btmCnt *fyne.Container
-
 	if len(groups) > 0 {
-		if len(groups[len(groups)-1]) == 0 {
-			groups = groups[:len(groups)-1]
-		}
-
 		for _, o := range groups[len(groups)-1] {
 			if isMin, _ := o.minimizer.Get(); isMin == 0 {
 				lastGpAllMin = false
 				break
 			}
 		}
+	}
+
+	return groups, lastGpAllMin
+}
 
+// structureOf returns a key identifying the container tree needed for the given groups
+func (sb *SideBar) structureOf(groups [][]*MiniWidget, lastGpAllMin bool) string {
+	structure := fmt.Sprint(sb.mode, lastGpAllMin)
+	if sb.mode == SideBarTabbed {
+		// minimized children do not change the tabs
+		structure = fmt.Sprint(sb.mode)
+		for _, g := range sb.tabGroups() {
+			structure += "|"
+			for _, o := range g {
+				structure += fmt.Sprintf("%p,", o)
+			}
+		}
+		return structure
+	}
+	for _, g := range groups {
+		structure += "|"
+		for _, o := range g {
+			structure += fmt.Sprintf("%p,", o)
+		}
+	}
+	return structure
+}
+
+// rebuild recreates the container tree, restoring the offsets of the splits whose top group starts with the same child
+func (sb *SideBar) rebuild(groups [][]*MiniWidget, lastGpAllMin bool) {
+	oldSplits := sb.mSplits
+	sb.mSplits = map[*MiniWidget]*container.Split{}
+	newSplit := func(topGp []*MiniWidget, top, btm fyne.CanvasObject) *container.Split {
+		split := container.NewVSplit(top, btm)
+		if offset, ok := sb.pendingOffsets[topGp[0].ID]; ok && topGp[0].ID != "" {
+			split.Offset = offset
+			delete(sb.pendingOffsets, topGp[0].ID)
+		} else if old, ok := oldSplits[topGp[0]]; ok {
+			split.Offset = old.Offset
+		}
+		sb.mSplits[topGp[0]] = split
+		return split
+	}
+
+	sb.mTree.RemoveAll()
+	if sb.mode == SideBarTabbed {
+		// each tab group is shown as the groups of the split mode, expanded
+		tabGroups := sb.tabGroups()
+		tabs := sb.refreshTabs(tabGroups)
+		if len(tabs) == 0 {
+			return
+		}
+		var btm fyne.CanvasObject = tabs[len(tabs)-1]
+		for i := len(tabs) - 2; i >= 0; i-- {
+			btm = newSplit(tabGroups[i], tabs[i], btm)
+		}
+		sb.mTree.Add(btm)
+		return
+	}
+
+	var top fyne.CanvasObject
+	var topCnt *fyne.Container
+	var btm fyne.CanvasObject
+	var btmCnt *fyne.Container
+
+	if len(groups) > 0 {
 		if lastGpAllMin {
 			btmCnt = container.NewVBox()
 		} else {
@@ -149,7 +605,7 @@ func (sb *SideBar) Refresh() {
 			btmCnt = container.New(&ExpandingFirstPaddedVBox{}, top, btm)
 			btm = btmCnt
 		} else {
-			btm = container.NewVSplit(top, btm)
+			btm = newSplit(groups[len(groups)-2], top, btm)
 		}
 	}
 
@@ -160,10 +616,314 @@ func (sb *SideBar) Refresh() {
 				topCnt.Add(o)
 			}
 			top = topCnt
-			btm = container.NewVSplit(top, btm)
+			btm = newSplit(groups[i], top, btm)
 		}
 	}
 	if btm != nil {
-		sb.mContent.Add(btm)
+		sb.mTree.Add(btm)
+	}
+}
+
+/*
+SideBarPanelLayout is the saved state of a MiniWidget in a SideBarLayout. TabGroup and Selected are the tab group of
+the MiniWidget, zero for the default one, and if its tab is the selected one of the group, see SideBar.GroupTabs
+*/
+type SideBarPanelLayout struct {
+	ID        string `json:"id"`
+	Minimized bool   `json:"minimized"`
+	Closed    bool   `json:"closed"`
+	TabGroup  int    `json:"tabGroup,omitempty"`
+	Selected  bool   `json:"selected,omitempty"`
+}
+
+/*
+SideBarLayout is the layout of a SideBar as saved by SideBar.SaveLayout: the order and the state of the children,
+and the split offsets keyed by the ID of the first child above each split. It can be stored as JSON.
+*/
+type SideBarLayout struct {
+	Panels  []SideBarPanelLayout `json:"panels"`
+	Offsets map[string]float64   `json:"offsets,omitempty"`
+}
+
+// SaveLayout returns the current layout of the SideBar. Children without ID are not saved
+func (sb *SideBar) SaveLayout() SideBarLayout {
+	l := SideBarLayout{Offsets: map[string]float64{}}
+	selected := map[*MiniWidget]bool{}
+	for _, o := range sb.selectedTabs() {
+		selected[o] = true
+	}
+	for _, o := range sb.mObjects {
+		if o.ID == "" {
+			continue
+		}
+		l.Panels = append(l.Panels, SideBarPanelLayout{
+			ID:        o.ID,
+			Minimized: o.IsMinimized(),
+			Closed:    o.IsClosed(),
+			TabGroup:  sb.mChildren[o].tabGroup,
+			// selections restored but not applied yet, because the SideBar is not tabbed
+			Selected: selected[o] || sb.pendingSelected[o.ID],
+		})
+	}
+	for o, split := range sb.mSplits {
+		if o.ID != "" {
+			l.Offsets[o.ID] = split.Offset
+		}
+	}
+	// offsets restored but not applied yet, because their split is not shown
+	for id, offset := range sb.pendingOffsets {
+		if _, ok := l.Offsets[id]; !ok {
+			l.Offsets[id] = offset
+		}
+	}
+	return l
+}
+
+/*
+RestoreLayout applies a layout saved with SaveLayout. Panels of the layout which are not children of the SideBar
+are ignored, while children missing from the layout keep their state and, as far as possible, their position.
+*/
+func (sb *SideBar) RestoreLayout(l SideBarLayout) {
+	byID := map[string]*MiniWidget{}
+	for _, o := range sb.mObjects {
+		if o.ID != "" {
+			byID[o.ID] = o
+		}
+	}
+
+	panels := make([]sideBarPanelState, 0, len(l.Panels))
+	sb.pendingSelected = map[string]bool{}
+	for _, p := range l.Panels {
+		if o, ok := byID[p.ID]; ok {
+			panels = append(panels, sideBarPanelState{mw: o, minimized: p.Minimized, closed: p.Closed, tabGroup: p.TabGroup})
+			if p.Selected {
+				sb.pendingSelected[p.ID] = true
+			}
+			if p.TabGroup > sb.nextTabGroup {
+				sb.nextTabGroup = p.TabGroup
+			}
+		}
+	}
+	changed := sb.applyPanels(panels)
+
+	sb.pendingOffsets = map[string]float64{}
+	for id, offset := range l.Offsets {
+		if _, ok := byID[id]; ok {
+			sb.pendingOffsets[id] = offset
+		}
+	}
+	// force the rebuild, so that the restored offsets are applied
+	sb.structure = ""
+	sb.Refresh()
+
+	if changed {
+		sb.notifyReordered()
+	}
+}
+
+// sideBarPanelState is the order and the state of a child, used to restore or reset the SideBar layout
+type sideBarPanelState struct {
+	mw        *MiniWidget
+	minimized bool
+	closed    bool
+	tabGroup  int
+}
+
+/*
+applyPanels reorders the children as in panels and applies their state. Children missing from panels keep their state
+and, as far as possible, their position. It returns true if the order changed, without refreshing the SideBar
+*/
+func (sb *SideBar) applyPanels(panels []sideBarPanelState) bool {
+	present := map[*MiniWidget]bool{}
+	for _, o := range sb.mObjects {
+		present[o] = true
+	}
+
+	newOrder := make([]*MiniWidget, 0, len(sb.mObjects))
+	saved := map[*MiniWidget]bool{}
+	for _, p := range panels {
+		o := p.mw
+		if !present[o] || saved[o] {
+			continue
+		}
+		saved[o] = true
+		newOrder = append(newOrder, o)
+		sb.mChildren[o].tabGroup = p.tabGroup
+
+		if p.minimized != o.IsMinimized() {
+			if p.minimized {
+				o.Minimize()
+			} else {
+				o.Restore()
+			}
+		}
+		if p.closed != o.IsClosed() {
+			if p.closed {
+				o.Close()
+			} else {
+				o.Reopen()
+			}
+		}
+	}
+	for i, o := range sb.mObjects {
+		if saved[o] {
+			continue
+		}
+		if i > len(newOrder) {
+			i = len(newOrder)
+		}
+		newOrder = append(newOrder[:i], append([]*MiniWidget{o}, newOrder[i:]...)...)
+	}
+
+	changed := false
+	for i := range newOrder {
+		if newOrder[i] != sb.mObjects[i] {
+			changed = true
+			break
+		}
+	}
+	sb.mObjects = newOrder
+	return changed
+}
+
+// ResetLayout restores the order and the state the children had when they were added, and the default split offsets
+func (sb *SideBar) ResetLayout() {
+	changed := sb.applyPanels(sb.defaults)
+
+	sb.pendingOffsets = nil
+	sb.mSplits = map[*MiniWidget]*container.Split{}
+	sb.structure = ""
+	sb.Refresh()
+
+	if changed {
+		sb.notifyReordered()
+	}
+}
+
+// ExpandAll restores all the minimized children
+func (sb *SideBar) ExpandAll() {
+	for _, o := range sb.mObjects {
+		if o.IsMinimized() {
+			o.Restore()
+		}
+	}
+}
+
+// CollapseAll minimizes all the children
+func (sb *SideBar) CollapseAll() {
+	for _, o := range sb.mObjects {
+		if !o.IsMinimized() {
+			o.Minimize()
+		}
+	}
+}
+
+/*
+ViewAction returns an ActionItem listing the children of the SideBar, to be used in an ActionableMenu, a MainRibbon or
+any other object built from ActionItem. Each child is a two state action (0 closed, 1 open) with a check icon, which
+closes or reopens it. The "Expand all", "Collapse all" and "Reset layout" actions follow in a separate group.
+
+The ActionItem is created the first time and then returned as is. Children added or removed later are added to it
+or removed from it: ActionableMenu built from it are updated, other objects list the children when they are built.
+*/
+func (sb *SideBar) ViewAction() *ActionItem {
+	if sb.mViewAction != nil {
+		return sb.mViewAction
+	}
+
+	sb.mViewPanels = NewActionItem("Panels", false, false, nil, false, false, false, 0, nil, nil)
+	for _, o := range sb.mObjects {
+		sb.addToggle(o)
+	}
+
+	layoutActions := NewActionItem("Layout", false, false, nil, false, false, false, 0, nil, []*ActionItem{
+		NewActionItem("Expand all", false, false, []fyne.Resource{theme.MenuExpandIcon()}, false, false, false, 0, func(int) {
+			sb.ExpandAll()
+		}, nil),
+		NewActionItem("Collapse all", false, false, []fyne.Resource{theme.MenuDropUpIcon()}, false, false, false, 0, func(int) {
+			sb.CollapseAll()
+		}, nil),
+		NewActionItem("Reset layout", false, false, []fyne.Resource{theme.ViewRefreshIcon()}, false, false, false, 0, func(int) {
+			sb.ResetLayout()
+		}, nil),
+	})
+
+	sb.mViewAction = NewActionItem("View", false, true, []fyne.Resource{theme.VisibilityIcon()}, false, false, false, 0, nil, []*ActionItem{
+		sb.mViewPanels,
+		layoutActions,
+	})
+	return sb.mViewAction
+}
+
+func (sb *SideBar) addToggle(o *MiniWidget) {
+	t := newSideBarPanelToggle(o)
+	sb.mChildren[o].toggle = t
+	sb.mViewPanels.AppendActions(t.mItem)
+}
+
+// sideBarPanelToggle keeps the state of the ActionItem of a child in sync with its closer
+type sideBarPanelToggle struct {
+	mw    *MiniWidget
+	mItem *ActionItem
+}
+
+func newSideBarPanelToggle(mw *MiniWidget) *sideBarPanelToggle {
+	t := &sideBarPanelToggle{mw: mw}
+	t.mItem = NewActionItem(mw.mLabel.mText.Text, true, false, []fyne.Resource{theme.CheckButtonIcon(), theme.CheckButtonCheckedIcon()}, false, false, true, 0, func(state int) {
+		if state == 1 {
+			mw.Close()
+		} else {
+			mw.Reopen()
+		}
+	}, nil)
+	if mw.texter != nil {
+		t.mItem.Name = mw.texter
+	}
+	t.DataChanged()
+	mw.closer.AddListener(t)
+	return t
+}
+
+func (t *sideBarPanelToggle) DataChanged() {
+	state := 1
+	if t.mw.IsClosed() {
+		state = 0
+	}
+	if st, _ := t.mItem.Stater.Get(); st != state {
+		t.mItem.Stater.Set(state)
+	}
+}
+
+// SaveLayoutJSON returns the current layout of the SideBar encoded as JSON
+func (sb *SideBar) SaveLayoutJSON() ([]byte, error) {
+	return json.Marshal(sb.SaveLayout())
+}
+
+// RestoreLayoutJSON applies a layout encoded as JSON by SaveLayoutJSON
+func (sb *SideBar) RestoreLayoutJSON(data []byte) error {
+	var l SideBarLayout
+	if err := json.Unmarshal(data, &l); err != nil {
+		return err
+	}
+	sb.RestoreLayout(l)
+	return nil
+}
+
+// SaveLayoutToPreferences stores the current layout of the SideBar as JSON in the preferences, under key
+func (sb *SideBar) SaveLayoutToPreferences(prefs fyne.Preferences, key string) error {
+	data, err := sb.SaveLayoutJSON()
+	if err != nil {
+		return err
+	}
+	prefs.SetString(key, string(data))
+	return nil
+}
+
+// RestoreLayoutFromPreferences applies the layout stored in the preferences under key, if any
+func (sb *SideBar) RestoreLayoutFromPreferences(prefs fyne.Preferences, key string) error {
+	data := prefs.String(key)
+	if data == "" {
+		return nil
 	}
+	return sb.RestoreLayoutJSON([]byte(data))
 }
@@ -0,0 +1,202 @@
+package fyneextensions
+
+import (
+	"slices"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/test"
+)
+
+// waitForBindings waits until the binding listeners queued so far, and the ones they queue, have been called
+func waitForBindings() {
+	for i := 0; i < 2; i++ {
+		done := make(chan struct{})
+		binding.NewBool().AddListener(binding.NewDataListener(func() {
+			close(done)
+		}))
+		<-done
+	}
+}
+
+// actionableMenuLeaves returns the labels of the leaves of the menu item tree, updated to the current ActionItem state
+func actionableMenuLeaves(ami *ActionableMenuItem) (labels []string) {
+	ami.DataChanged()
+	if len(ami.subActionableMenuItems) == 0 {
+		return []string{ami.mItem.Label}
+	}
+	for _, o := range ami.subActionableMenuItems {
+		labels = append(labels, actionableMenuLeaves(o)...)
+	}
+	return
+}
+
+func TestSideBarViewActionMenuFollowsPanels(t *testing.T) {
+	a := test.NewApp()
+	defer a.Quit()
+	w := a.NewWindow("sidebar")
+	defer w.Close()
+
+	first := newTestMiniWidget("first", w.Canvas())
+	second := newTestMiniWidget("second", w.Canvas())
+	sb := NewSideBar(first, second)
+	w.SetContent(sb)
+
+	am := NewActionableMenu(sb.ViewAction())
+	am.DataChanged()
+	if got := actionableMenuLeaves(am.mActionableMenuItem); !slices.Contains(got, "first") || !slices.Contains(got, "second") {
+		t.Fatalf("menu lists %v, want first and second", got)
+	}
+
+	third := newTestMiniWidget("third", w.Canvas())
+	sb.AddWidget(third)
+	sb.RemoveWidget(first)
+	am.DataChanged()
+	got := actionableMenuLeaves(am.mActionableMenuItem)
+	if !slices.Contains(got, "third") {
+		t.Errorf("menu lists %v, the added panel is missing", got)
+	}
+	if slices.Contains(got, "first") {
+		t.Errorf("menu lists %v, the removed panel is still listed", got)
+	}
+}
+
+func TestSideBarTabGroupsRoundTrip(t *testing.T) {
+	a := test.NewApp()
+	defer a.Quit()
+	w := a.NewWindow("sidebar")
+	defer w.Close()
+
+	first := newTestMiniWidget("first", w.Canvas())
+	second := newTestMiniWidget("second", w.Canvas())
+	third := newTestMiniWidget("third", w.Canvas())
+	fourth := newTestMiniWidget("fourth", w.Canvas())
+	sb := NewSideBar(first, second, third, fourth)
+	w.SetContent(sb)
+	waitForBindings()
+	sb.SetMode(SideBarTabbed)
+
+	sb.GroupTabs(second, fourth)
+	sb.SelectTab(fourth)
+	groups := sb.TabGroups()
+	if len(groups) != 2 || !slices.Equal(groups[0], []*MiniWidget{first, third}) || !slices.Equal(groups[1], []*MiniWidget{second, fourth}) {
+		t.Fatalf("tab groups are %v, want [[first third] [second fourth]]", groups)
+	}
+
+	l := sb.SaveLayout()
+	sb.UngroupTabs(second, fourth)
+	if got := sb.TabGroups(); len(got) != 1 {
+		t.Fatalf("%d tab groups after ungrouping, want 1", len(got))
+	}
+
+	sb.RestoreLayout(l)
+	waitForBindings()
+	if got := sb.TabGroups(); len(got) != 2 || !slices.Equal(got[1], []*MiniWidget{second, fourth}) {
+		t.Errorf("tab groups after restore are %v, want [[first third] [second fourth]]", got)
+	}
+	if got := sb.selectedTabs(); len(got) != 2 || got[1] != fourth {
+		t.Errorf("selected tabs after restore are %v, want fourth selected in the second group", got)
+	}
+}
+
+func TestSideBarSaveRestoreLayout(t *testing.T) {
+	a := test.NewApp()
+	defer a.Quit()
+	w := a.NewWindow("sidebar")
+	defer w.Close()
+
+	first := newTestMiniWidget("first", w.Canvas())
+	second := newTestMiniWidget("second", w.Canvas())
+	third := newTestMiniWidget("third", w.Canvas())
+	sb := NewSideBar(first, second, third)
+	w.SetContent(sb)
+	w.Resize(fyne.NewSize(300., 600.))
+
+	second.Minimize()
+	waitForBindings()
+	split, ok := sb.mSplits[first]
+	if !ok {
+		t.Fatal("no split below the first child")
+	}
+	split.SetOffset(.3)
+
+	data, err := sb.SaveLayoutJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sb.MoveTo(first, 2)
+	second.Restore()
+	third.Close()
+	split.SetOffset(.7)
+	waitForBindings()
+
+	if err := sb.RestoreLayoutJSON(data); err != nil {
+		t.Fatal(err)
+	}
+	waitForBindings()
+	if got := sb.Widgets(); !slices.Equal(got, []*MiniWidget{first, second, third}) {
+		t.Errorf("order after restore is %v, want [first second third]", got)
+	}
+	if !second.IsMinimized() || first.IsMinimized() {
+		t.Error("minimized state is not restored")
+	}
+	if third.IsClosed() {
+		t.Error("closed state is not restored")
+	}
+	if split, ok := sb.mSplits[first]; !ok || split.Offset != .3 {
+		t.Errorf("split offset below the first child is not restored to 0.3")
+	}
+
+	if err := sb.RestoreLayoutJSON([]byte("{")); err == nil {
+		t.Error("invalid JSON is restored without error")
+	}
+}
+
+func TestSideBarInsertMoveRemove(t *testing.T) {
+	a := test.NewApp()
+	defer a.Quit()
+	w := a.NewWindow("sidebar")
+	defer w.Close()
+
+	first := newTestMiniWidget("first", w.Canvas())
+	second := newTestMiniWidget("second", w.Canvas())
+	sb := NewSideBar(first, second)
+	w.SetContent(sb)
+	waitForBindings()
+
+	var reordered []*MiniWidget
+	sb.OnReordered = func(order []*MiniWidget) {
+		reordered = order
+	}
+
+	third := newTestMiniWidget("third", w.Canvas())
+	sb.InsertWidget(1, third, first)
+	if got := sb.Widgets(); !slices.Equal(got, []*MiniWidget{first, third, second}) {
+		t.Fatalf("order after insert is %v, want [first third second]", got)
+	}
+	sb.InsertWidget(-5, newTestMiniWidget("zero", w.Canvas()))
+	if got := sb.IndexOf(first); got != 1 {
+		t.Errorf("first is at %d after inserting at a negative position, want 1", got)
+	}
+
+	sb.MoveTo(first, 10)
+	if got := sb.IndexOf(first); got != 3 {
+		t.Errorf("first is at %d after moving past the end, want 3", got)
+	}
+	if len(reordered) != 4 || reordered[3] != first {
+		t.Errorf("OnReordered is called with %v, want first last", reordered)
+	}
+
+	sb.RemoveWidget(third, newTestMiniWidget("other", w.Canvas()))
+	if got := sb.IndexOf(third); got != -1 {
+		t.Errorf("removed child is at %d, want -1", got)
+	}
+	if len(sb.Widgets()) != 3 {
+		t.Errorf("%d children after remove, want 3", len(sb.Widgets()))
+	}
+	if third.onMoveUp != nil || third.onDragged != nil {
+		t.Error("removed child still calls the SideBar")
+	}
+}
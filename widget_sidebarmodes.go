@@ -0,0 +1,235 @@
+package fyneextensions
+
+import (
+	"fyne.io/fyne/v2/container"
+)
+
+// SideBarMode defines how a SideBar groups its children
+type SideBarMode int
+
+const (
+	// SideBarSplit groups the children in groups ending with an expanded child, joined by vertical splits
+	SideBarSplit SideBarMode = iota
+	// SideBarTabbed shows the children of each tab group as tabs sharing space, see GroupTabs
+	SideBarTabbed
+	// SideBarAccordion lets a single child be expanded: expanding a child minimizes the others
+	SideBarAccordion
+)
+
+/*
+SetMode changes how the SideBar groups its children. Children, their order and their state are kept, so the
+MiniWidget API and the saved layouts work the same way in all modes. In SideBarTabbed mode dragging a header does
+not reorder the children, while the move buttons do.
+*/
+func (sb *SideBar) SetMode(mode SideBarMode) {
+	if mode == sb.mode {
+		return
+	}
+	sb.mode = mode
+	sb.lastExpanded = nil
+	sb.cancelDrag()
+	sb.structure = ""
+	sb.Refresh()
+}
+
+// Mode returns how the SideBar groups its children
+func (sb *SideBar) Mode() SideBarMode {
+	return sb.mode
+}
+
+/*
+GroupTabs puts the given children in a new tab group. In SideBarTabbed mode the children of a tab group share space
+as tabs, while the children not grouped share the default tab group. Tab groups are stacked top-to-bottom, in the
+order of their first child, and joined by vertical splits. Tab groups are saved with the layout
+*/
+func (sb *SideBar) GroupTabs(widgets ...*MiniWidget) {
+	sb.nextTabGroup++
+	for _, o := range widgets {
+		if c, ok := sb.mChildren[o]; ok {
+			c.tabGroup = sb.nextTabGroup
+		}
+	}
+	sb.Refresh()
+}
+
+// UngroupTabs moves the given children back to the default tab group
+func (sb *SideBar) UngroupTabs(widgets ...*MiniWidget) {
+	for _, o := range widgets {
+		if c, ok := sb.mChildren[o]; ok {
+			c.tabGroup = 0
+		}
+	}
+	sb.Refresh()
+}
+
+// TabGroups returns the visible children of each tab group, in display order
+func (sb *SideBar) TabGroups() [][]*MiniWidget {
+	return sb.tabGroups()
+}
+
+// tabGroups splits the visible children in tab groups, ordered by their first child
+func (sb *SideBar) tabGroups() [][]*MiniWidget {
+	index := map[int]int{}
+	groups := make([][]*MiniWidget, 0)
+	for _, o := range sb.visibleChildren() {
+		id := sb.mChildren[o].tabGroup
+		i, ok := index[id]
+		if !ok {
+			i = len(groups)
+			index[id] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], o)
+	}
+	return groups
+}
+
+// SelectedTab returns the child of the selected tab of the first tab group in SideBarTabbed mode, nil otherwise
+func (sb *SideBar) SelectedTab() *MiniWidget {
+	if selected := sb.selectedTabs(); len(selected) > 0 {
+		return selected[0]
+	}
+	return nil
+}
+
+// selectedTabs returns the child of the selected tab of each tab group in SideBarTabbed mode, in display order
+func (sb *SideBar) selectedTabs() (selected []*MiniWidget) {
+	if sb.mode != SideBarTabbed {
+		return nil
+	}
+	for _, g := range sb.tabGroups() {
+		if mw := selectedTabOf(sb.mTabs[sb.mChildren[g[0]].tabGroup]); mw != nil {
+			selected = append(selected, mw)
+		}
+	}
+	return
+}
+
+func selectedTabOf(tabs *container.AppTabs) *MiniWidget {
+	if tabs == nil {
+		return nil
+	}
+	if item := tabs.Selected(); item != nil {
+		if mw, ok := item.Content.(*MiniWidget); ok {
+			return mw
+		}
+	}
+	return nil
+}
+
+// SelectTab selects the tab of mw in its tab group, in SideBarTabbed mode
+func (sb *SideBar) SelectTab(mw *MiniWidget) {
+	c, ok := sb.mChildren[mw]
+	if !ok || sb.mTabs[c.tabGroup] == nil {
+		return
+	}
+	tabs := sb.mTabs[c.tabGroup]
+	for _, item := range tabs.Items {
+		if item.Content == mw {
+			tabs.Select(item)
+			return
+		}
+	}
+}
+
+/*
+applyAccordion minimizes all the expanded children but one: the one expanded last, or the first one. It returns
+true if any child has been minimized
+*/
+func (sb *SideBar) applyAccordion() bool {
+	expanded := make([]*MiniWidget, 0)
+	for _, o := range sb.visibleChildren() {
+		if !o.IsMinimized() {
+			expanded = append(expanded, o)
+		}
+	}
+
+	changed := false
+	if len(expanded) > 1 {
+		keep := expanded[0]
+		for _, o := range expanded {
+			if !sb.lastExpanded[o] {
+				keep = o
+				break
+			}
+		}
+		for _, o := range expanded {
+			if o != keep {
+				o.Minimize()
+				changed = true
+			}
+		}
+		expanded = []*MiniWidget{keep}
+	}
+
+	sb.lastExpanded = map[*MiniWidget]bool{}
+	for _, o := range expanded {
+		sb.lastExpanded[o] = true
+	}
+	return changed
+}
+
+/*
+refreshTabs updates the tabs of each tab group with its visible children, keeping the selected ones if possible,
+and returns them in the order of tabGroups
+*/
+func (sb *SideBar) refreshTabs(tabGroups [][]*MiniWidget) []*container.AppTabs {
+	oldTabs := sb.mTabs
+	sb.mTabs = map[int]*container.AppTabs{}
+
+	retV := make([]*container.AppTabs, 0, len(tabGroups))
+	for _, g := range tabGroups {
+		id := sb.mChildren[g[0]].tabGroup
+		tabs, ok := oldTabs[id]
+		if !ok {
+			tabs = container.NewAppTabs()
+			tabs.OnSelected = func(item *container.TabItem) {
+				// the content of the selected child is always shown
+				if mw, ok := item.Content.(*MiniWidget); ok && mw.IsMinimized() {
+					mw.Restore()
+				}
+			}
+		}
+		sb.mTabs[id] = tabs
+
+		selected := selectedTabOf(tabs)
+		for _, o := range g {
+			if sb.pendingSelected[o.ID] && o.ID != "" {
+				selected = o
+				delete(sb.pendingSelected, o.ID)
+			}
+		}
+
+		items := make([]*container.TabItem, 0, len(g))
+		var selItem *container.TabItem
+		for _, o := range g {
+			item := container.NewTabItem(o.mLabel.mText.Text, o)
+			if o == selected {
+				selItem = item
+			}
+			items = append(items, item)
+		}
+		tabs.SetItems(items)
+		if selItem != nil {
+			tabs.Select(selItem)
+		}
+		retV = append(retV, tabs)
+	}
+	return retV
+}
+
+// refreshTabTitles updates the tab titles with the header text of the children
+func (sb *SideBar) refreshTabTitles() {
+	for _, tabs := range sb.mTabs {
+		changed := false
+		for _, item := range tabs.Items {
+			if mw, ok := item.Content.(*MiniWidget); ok && item.Text != mw.mLabel.mText.Text {
+				item.Text = mw.mLabel.mText.Text
+				changed = true
+			}
+		}
+		if changed {
+			tabs.Refresh()
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package fyneextensions
+
+import (
+	"fmt"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+/*
+SetCollapsed collapses the SideBar to a thin vertical rail with one icon per child (true), or shows its children
+again (false). The icon of a child is defined with MiniWidget.SetIcon. A click on an icon shows the child in a
+flyout or expands the SideBar, as defined by FlyoutOnClick.
+When CollapseWidth is greater than zero, the SideBar also collapses and expands automatically when resized.
+*/
+func (sb *SideBar) SetCollapsed(collapsed bool) {
+	if !collapsed {
+		sb.hideFlyout()
+	}
+	if collapsed == sb.isCollapsed {
+		return
+	}
+	sb.isCollapsed = collapsed
+	sb.cancelDrag()
+
+	if collapsed {
+		sb.mTree.Hide()
+		sb.mRail.Show()
+	} else {
+		sb.mRail.Hide()
+		sb.mTree.Show()
+	}
+	sb.railStructure = ""
+	sb.Refresh()
+	sb.mContent.Refresh()
+
+	if sb.OnCollapsedChanged != nil {
+		sb.OnCollapsedChanged(collapsed)
+	}
+}
+
+// IsCollapsed returns true if the SideBar shows the icon rail
+func (sb *SideBar) IsCollapsed() bool {
+	return sb.isCollapsed
+}
+
+// Resize collapses or expands the SideBar when its width crosses CollapseWidth
+func (sb *SideBar) Resize(size fyne.Size) {
+	sb.BaseWidget.Resize(size)
+
+	if sb.CollapseWidth <= 0 {
+		return
+	}
+	isNarrow := size.Width < sb.CollapseWidth
+	if isNarrow != sb.isNarrow {
+		sb.isNarrow = isNarrow
+		sb.SetCollapsed(isNarrow)
+	}
+}
+
+func (sb *SideBar) iconChanged() {
+	sb.railStructure = ""
+	sb.Refresh()
+}
+
+// refreshRail recreates the rail buttons when the collapsed SideBar children change
+func (sb *SideBar) refreshRail() {
+	if !sb.isCollapsed {
+		return
+	}
+
+	children := make([]*MiniWidget, 0, len(sb.mObjects))
+	structure := ""
+	for _, o := range sb.mObjects {
+		if !o.IsClosed() {
+			children = append(children, o)
+			structure += fmt.Sprintf("%p,", o)
+		}
+	}
+	if structure == sb.railStructure {
+		return
+	}
+	sb.railStructure = structure
+
+	for _, o := range sb.railButtons {
+		o.unbind()
+	}
+	sb.railButtons = nil
+	sb.mRail.RemoveAll()
+
+	var mCanvas fyne.Canvas
+	if len(children) > 0 {
+		mCanvas = children[0].mCanvas
+	}
+	expandBtn := NewFlexButton("Expand", []fyne.Resource{theme.NavigateNextIcon()}, true, true, true, false, false, sb.RailSize, sb.RailSize*.5, mCanvas, func(int) {
+		sb.SetCollapsed(false)
+	}, nil, nil, nil, nil, nil)
+	sb.railButtons = append(sb.railButtons, expandBtn)
+	sb.mRail.Add(expandBtn)
+	sb.mRail.Add(widget.NewSeparator())
+
+	for _, o := range children {
+		mw := o
+		icon := mw.Icon()
+		if icon == nil {
+			icon = theme.ListIcon()
+		}
+		btn := NewFlexButton(mw.mLabel.mText.Text, []fyne.Resource{icon}, true, true, true, false, false, sb.RailSize, sb.RailSize*.5, mw.mCanvas, func(int) {
+			sb.railTapped(mw)
+		}, mw.texter, nil, nil, nil, nil)
+		sb.railButtons = append(sb.railButtons, btn)
+		sb.mRail.Add(btn)
+	}
+}
+
+// railTapped shows mw in the flyout, or expands the SideBar showing mw
+func (sb *SideBar) railTapped(mw *MiniWidget) {
+	if mw.IsClosed() {
+		mw.Reopen()
+	}
+	if mw.IsMinimized() {
+		mw.Restore()
+	}
+
+	if !sb.FlyoutOnClick {
+		sb.SetCollapsed(false)
+		return
+	}
+	if sb.mFlyout != nil && sb.mFlyout.mw == mw {
+		sb.hideFlyout()
+		return
+	}
+	sb.showFlyout(mw)
+}
+
+// showFlyout shows mw in an overlay next to the rail, moving it out of the SideBar tree until hidden
+func (sb *SideBar) showFlyout(mw *MiniWidget) {
+	mCanvas := fyne.CurrentApp().Driver().CanvasForObject(sb)
+	if mCanvas == nil {
+		return
+	}
+	sb.hideFlyout()
+
+	sb.mFlyout = newSideBarFlyout(sb, mw, mCanvas)
+	sb.structure = ""
+	sb.Refresh()
+
+	d := fyne.CurrentApp().Driver()
+	pos := d.AbsolutePositionForObject(sb.mRail)
+	pos.X += sb.mRail.Size().Width
+	width := sb.FlyoutWidth
+	if width <= 0 {
+		width = mw.MinSize().Width
+	}
+	height := sb.Size().Height
+	if mh := mw.MinSize().Height; height < mh {
+		height = mh
+	}
+	sb.mFlyout.show(pos, fyne.NewSize(width, height))
+}
+
+// hideFlyout hides the flyout, if visible, and puts its child back in the SideBar tree
+func (sb *SideBar) hideFlyout() {
+	if sb.mFlyout == nil {
+		return
+	}
+	sb.mFlyout.hide()
+	sb.mFlyout = nil
+	sb.structure = ""
+	sb.Refresh()
+}
+
+/*
+sideBarFlyout is the overlay showing a child of a collapsed SideBar. It covers the whole canvas, so that a tap
+outside the child hides it
+*/
+type sideBarFlyout struct {
+	widget.BaseWidget
+
+	sb      *SideBar
+	mw      *MiniWidget
+	mCanvas fyne.Canvas
+
+	mShadow    *canvas.Rectangle
+	mContainer *fyne.Container
+}
+
+func newSideBarFlyout(sb *SideBar, mw *MiniWidget, mCanvas fyne.Canvas) *sideBarFlyout {
+	f := &sideBarFlyout{
+		sb:      sb,
+		mw:      mw,
+		mCanvas: mCanvas,
+		mShadow: canvas.NewRectangle(theme.ShadowColor()),
+	}
+	f.mContainer = container.NewWithoutLayout(f.mShadow, mw)
+	f.ExtendBaseWidget(f)
+	return f
+}
+
+func (f *sideBarFlyout) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(f.mContainer)
+}
+
+func (f *sideBarFlyout) show(pos fyne.Position, size fyne.Size) {
+	f.mw.Move(pos)
+	f.mw.Resize(size)
+	f.mShadow.FillColor = theme.ShadowColor()
+	f.mShadow.Move(pos.Add(fyne.NewPos(theme.Padding()/2., theme.Padding()/2.)))
+	f.mShadow.Resize(size)
+
+	f.Resize(f.mCanvas.Size())
+	f.mCanvas.Overlays().Add(f)
+}
+
+func (f *sideBarFlyout) hide() {
+	f.mCanvas.Overlays().Remove(f)
+}
+
+// Tapped hides the flyout when the tap is outside the child
+func (f *sideBarFlyout) Tapped(ev *fyne.PointEvent) {
+	pos, size := f.mw.Position(), f.mw.Size()
+	if ev.Position.X < pos.X || ev.Position.X > pos.X+size.Width || ev.Position.Y < pos.Y || ev.Position.Y > pos.Y+size.Height {
+		f.sb.hideFlyout()
+	}
+}